@@ -0,0 +1,223 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package blockio
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// DefaultBlockSize is the default number of data bytes stored in each block.
+const DefaultBlockSize = 64 * 1024
+
+// A block failed the checksum verification.
+var ErrCorruptBlock = errors.New("blockio: corrupt block")
+
+// CorruptBlockError describes which block failed the checksum verification.
+type CorruptBlockError struct {
+	Block          uint64 // Index of the block
+	Offset         uint64 // Offset of the block's data within the original (unblocked) data
+	PhysicalOffset uint64 // Offset of the block within the blocked stream
+}
+
+func (e *CorruptBlockError) Error() string {
+	return fmt.Sprintf("blockio: block [%d] at offset %d (physical offset %d) is corrupt", e.Block, e.Offset, e.PhysicalOffset)
+}
+
+func (e *CorruptBlockError) Unwrap() error {
+	return ErrCorruptBlock
+}
+
+// NewHashFn creates the hash.Hash used to calculate the checksum of each block.
+// For example: ajhash.AlgoSHA256.Hasher.
+type NewHashFn func() hash.Hash
+
+// CRC32 creates a hash.Hash that calculates a CRC-32 checksum using the Castagnoli polynomial.
+// This is the default checksum used.
+func CRC32() hash.Hash {
+	return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+}
+
+//-----------------------------------------------------------------------------
+
+// Writer writes data in fixed-size blocks where each block is followed by a checksum.
+type Writer struct {
+	w         io.Writer
+	hasher    hash.Hash
+	buf       []byte
+	blockSize int
+}
+
+// Create a new Writer that writes blocks of blockSize bytes to w using newHash to calculate the checksums.
+// A blockSize of 0 or less will use [DefaultBlockSize] and a nil newHash will use [CRC32].
+func NewWriter(w io.Writer, blockSize int, newHash NewHashFn) *Writer {
+	if blockSize <= 0 {
+		blockSize = DefaultBlockSize
+	}
+	if newHash == nil {
+		newHash = CRC32
+	}
+
+	hasher := newHash()
+	return &Writer{
+		w:         w,
+		hasher:    hasher,
+		buf:       make([]byte, 0, blockSize+hasher.Size()), // room for the checksum to be appended
+		blockSize: blockSize,
+	}
+}
+
+// io.Writer.
+func (w *Writer) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(w.blockSize-len(w.buf), len(p))
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+		written += n
+
+		if len(w.buf) == w.blockSize {
+			if err := w.writeBlock(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Write any buffered data as the final (possibly shorter) block.
+// Close does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	return w.writeBlock()
+}
+
+func (w *Writer) writeBlock() error {
+	w.hasher.Reset()
+	_, _ = w.hasher.Write(w.buf)
+	block := w.hasher.Sum(w.buf)
+
+	if _, err := w.w.Write(block); err != nil {
+		return fmt.Errorf("failed to write the block. %w", err)
+	}
+	w.buf = w.buf[:0]
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Reader reads data that was written by a Writer and verifies the checksum of each block.
+type Reader struct {
+	r          io.Reader
+	hasher     hash.Hash
+	blockSize  int
+	buf        []byte // the current block including the checksum
+	data       []byte // unread verified data of the current block
+	block      uint64
+	sumScratch []byte
+	err        error
+}
+
+// Create a new Reader that reads blocks of blockSize bytes from r using newHash to verify the checksums.
+// The blockSize and newHash must be the same as those used by the Writer.
+// A blockSize of 0 or less will use [DefaultBlockSize] and a nil newHash will use [CRC32].
+func NewReader(r io.Reader, blockSize int, newHash NewHashFn) *Reader {
+	if blockSize <= 0 {
+		blockSize = DefaultBlockSize
+	}
+	if newHash == nil {
+		newHash = CRC32
+	}
+
+	hasher := newHash()
+	return &Reader{
+		r:         r,
+		hasher:    hasher,
+		blockSize: blockSize,
+		buf:       make([]byte, blockSize+hasher.Size()),
+	}
+}
+
+// io.Reader.
+// Returns a [*CorruptBlockError] when a block fails the checksum verification.
+func (r *Reader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if err := r.readBlock(); err != nil {
+			r.err = err
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func (r *Reader) readBlock() error {
+	sumSize := r.hasher.Size()
+
+	n, err := io.ReadFull(r.r, r.buf)
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return err
+	}
+
+	if n <= sumSize {
+		return r.corrupt()
+	}
+
+	data := r.buf[:n-sumSize]
+	sum := r.buf[n-sumSize : n]
+
+	r.hasher.Reset()
+	_, _ = r.hasher.Write(data)
+	r.sumScratch = r.hasher.Sum(r.sumScratch[:0])
+	if string(r.sumScratch) != string(sum) {
+		return r.corrupt()
+	}
+
+	if n < len(r.buf) {
+		// The last block has been read
+		r.err = io.EOF
+	}
+
+	r.data = data
+	r.block++
+	return nil
+}
+
+func (r *Reader) corrupt() error {
+	return &CorruptBlockError{
+		Block:          r.block,
+		Offset:         r.block * uint64(r.blockSize),
+		PhysicalOffset: r.block * uint64(len(r.buf)),
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package blockio_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajhash"
+	"github.com/andrejacobs/go-aj/ajio/blockio"
+	"github.com/andrejacobs/go-aj/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	for _, size := range []int{0, 1, 15, 16, 17, 100, 160} {
+		expected := make([]byte, size)
+		require.NoError(t, random.SecureBytes(expected))
+
+		var stored bytes.Buffer
+		w := blockio.NewWriter(&stored, 16, nil)
+		n, err := w.Write(expected)
+		require.NoError(t, err)
+		assert.Equal(t, size, n)
+		require.NoError(t, w.Close())
+
+		blocks := (size + 15) / 16
+		assert.Equal(t, size+blocks*4, stored.Len())
+
+		r := blockio.NewReader(&stored, 16, nil)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, expected, data)
+	}
+}
+
+func TestWriteAndReadWithAjhash(t *testing.T) {
+	expected := []byte("The quick brown fox jumped over the lazy dog!")
+
+	var stored bytes.Buffer
+	w := blockio.NewWriter(&stored, 10, ajhash.AlgoSHA256.Hasher)
+	for _, b := range expected {
+		_, err := w.Write([]byte{b})
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	assert.Equal(t, len(expected)+5*ajhash.AlgoSHA256.Size(), stored.Len())
+
+	r := blockio.NewReader(&stored, 10, ajhash.AlgoSHA256.Hasher)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+}
+
+func TestReadCorruptBlock(t *testing.T) {
+	expected := []byte("The quick brown fox jumped over the lazy dog!")
+
+	var stored bytes.Buffer
+	w := blockio.NewWriter(&stored, 10, nil)
+	_, err := w.Write(expected)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// Corrupt the 3rd block
+	corrupted := stored.Bytes()
+	corrupted[2*14+3] ^= 0xFF
+
+	r := blockio.NewReader(bytes.NewReader(corrupted), 10, nil)
+	data, err := io.ReadAll(r)
+	assert.ErrorIs(t, err, blockio.ErrCorruptBlock)
+	assert.Equal(t, expected[:20], data)
+
+	var blockErr *blockio.CorruptBlockError
+	require.True(t, errors.As(err, &blockErr))
+	assert.Equal(t, uint64(2), blockErr.Block)
+	assert.Equal(t, uint64(20), blockErr.Offset)
+	assert.Equal(t, uint64(28), blockErr.PhysicalOffset)
+	assert.Contains(t, blockErr.Error(), "block [2]")
+
+	// The error is sticky
+	_, err = r.Read(make([]byte, 4))
+	assert.ErrorIs(t, err, blockio.ErrCorruptBlock)
+}
+
+func TestReadTruncated(t *testing.T) {
+	var stored bytes.Buffer
+	w := blockio.NewWriter(&stored, 10, nil)
+	_, err := w.Write([]byte("The quick brown fox"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// Only part of the checksum of the first block
+	r := blockio.NewReader(bytes.NewReader(stored.Bytes()[:12]), 10, nil)
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, blockio.ErrCorruptBlock)
+
+	// Only part of the checksum of the last block
+	r = blockio.NewReader(bytes.NewReader(stored.Bytes()[:stored.Len()-1]), 10, nil)
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, blockio.ErrCorruptBlock)
+}
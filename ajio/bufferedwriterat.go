@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"fmt"
+	"io"
+)
+
+// DefaultBufferedWriterAtSize is the default size of the buffer used by [BufferedWriterAt].
+const DefaultBufferedWriterAtSize = 1024 * 1024
+
+// BufferedWriterAt batches sequential positioned writes into larger writes to an underlying [io.WriterAt].
+// Data is accumulated for as long as each write continues where the previous one ended. The buffer is
+// flushed when it is full, when a write does not follow on from the buffered data (a gap or overlap),
+// on Seek or when Flush is called.
+//
+// Flush must be called after the last write to ensure all data has been written.
+type BufferedWriterAt struct {
+	w      io.WriterAt
+	buf    []byte
+	bufOff int64 // the offset at which the buffered data will be written
+	off    int64 // the current offset used by Write
+	err    error
+}
+
+// Create a new BufferedWriterAt that writes to w using a buffer of size bytes.
+// If size is not positive then [DefaultBufferedWriterAtSize] is used.
+func NewBufferedWriterAt(w io.WriterAt, size int) *BufferedWriterAt {
+	if size <= 0 {
+		size = DefaultBufferedWriterAtSize
+	}
+	return &BufferedWriterAt{
+		w:   w,
+		buf: make([]byte, 0, size),
+	}
+}
+
+// io.WriterAt.
+// Buffered data is flushed first when p is not a continuation of the buffered data.
+// Writes that are at least as large as the buffer bypass the buffer.
+func (b *BufferedWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	if len(b.buf) > 0 && off != b.bufOff+int64(len(b.buf)) {
+		if err := b.Flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(b.buf) == 0 {
+		b.bufOff = off
+		if len(p) >= cap(b.buf) {
+			n, err := b.w.WriteAt(p, off)
+			if err != nil {
+				b.err = err
+			}
+			return n, err
+		}
+	}
+
+	n := 0
+	for len(p) > 0 {
+		copied := copy(b.buf[len(b.buf):cap(b.buf)], p)
+		b.buf = b.buf[:len(b.buf)+copied]
+		n += copied
+		p = p[copied:]
+
+		if len(b.buf) == cap(b.buf) {
+			if err := b.Flush(); err != nil {
+				return n, err
+			}
+			b.bufOff = off + int64(n)
+		}
+	}
+	return n, nil
+}
+
+// io.Writer.
+// Writes p at the current offset and advances the offset.
+func (b *BufferedWriterAt) Write(p []byte) (int, error) {
+	n, err := b.WriteAt(p, b.off)
+	b.off += int64(n)
+	return n, err
+}
+
+// io.Seeker.
+// Any buffered data is flushed before the offset is changed.
+// io.SeekEnd is not supported because the size of the underlying io.WriterAt is not known.
+func (b *BufferedWriterAt) Seek(offset int64, whence int) (int64, error) {
+	if err := b.Flush(); err != nil {
+		return b.off, err
+	}
+
+	switch whence {
+	default:
+		return 0, errWhence
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += b.off
+	}
+
+	if offset < 0 {
+		return 0, errOffset
+	}
+	b.off = offset
+	return offset, nil
+}
+
+// Write any buffered data to the underlying io.WriterAt.
+// Once a write to the underlying io.WriterAt has failed, all further calls return the same error.
+func (b *BufferedWriterAt) Flush() error {
+	if b.err != nil {
+		return b.err
+	}
+	if len(b.buf) == 0 {
+		return nil
+	}
+
+	n, err := b.w.WriteAt(b.buf, b.bufOff)
+	if err == nil && n < len(b.buf) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		b.err = fmt.Errorf("failed to flush %d bytes at offset %d. %w", len(b.buf), b.bufOff, err)
+		return b.err
+	}
+
+	b.buf = b.buf[:0]
+	return nil
+}
+
+// Return the number of bytes that have been buffered but not yet written.
+func (b *BufferedWriterAt) Buffered() int {
+	return len(b.buf)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingWriterAt struct {
+	data  []byte
+	calls int
+	err   error
+}
+
+func (r *recordingWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.calls++
+	if end := int(off) + len(p); end > len(r.data) {
+		r.data = append(r.data, make([]byte, end-len(r.data))...)
+	}
+	copy(r.data[off:], p)
+	return len(p), nil
+}
+
+func TestBufferedWriterAtBatchesSequentialWrites(t *testing.T) {
+	rec := &recordingWriterAt{}
+	w := ajio.NewBufferedWriterAt(rec, 8)
+
+	for i, chunk := range []string{"ab", "cd", "ef"} {
+		_, err := w.WriteAt([]byte(chunk), int64(i*2))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, rec.calls)
+	assert.Equal(t, 6, w.Buffered())
+
+	// Fills the buffer which is then flushed
+	_, err := w.WriteAt([]byte("ghij"), 6)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rec.calls)
+	assert.Equal(t, 2, w.Buffered())
+
+	// A gap flushes the buffer
+	_, err = w.WriteAt([]byte("z"), 20)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rec.calls)
+
+	require.NoError(t, w.Flush())
+	assert.Equal(t, 3, rec.calls)
+	assert.Equal(t, 0, w.Buffered())
+
+	assert.Equal(t, "abcdefghij", string(rec.data[:10]))
+	assert.Equal(t, byte('z'), rec.data[20])
+}
+
+func TestBufferedWriterAtLargeWriteBypassesBuffer(t *testing.T) {
+	rec := &recordingWriterAt{}
+	w := ajio.NewBufferedWriterAt(rec, 4)
+
+	_, err := w.WriteAt([]byte("0123456789"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rec.calls)
+	assert.Equal(t, 0, w.Buffered())
+}
+
+func TestBufferedWriterAtWriteAndSeek(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "test.bin"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := ajio.NewBufferedWriterAt(f, 0)
+	_, err = w.Write([]byte("Hello "))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("world"))
+	require.NoError(t, err)
+
+	pos, err := w.Seek(-5, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), pos)
+	_, err = w.Write([]byte("W"))
+	require.NoError(t, err)
+
+	_, err = w.Seek(0, io.SeekEnd)
+	assert.Error(t, err)
+	_, err = w.Seek(-100, io.SeekCurrent)
+	assert.Error(t, err)
+
+	require.NoError(t, w.Flush())
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World", string(data))
+}
+
+func TestBufferedWriterAtFlushError(t *testing.T) {
+	expectedErr := errors.New("disk full")
+	rec := &recordingWriterAt{err: expectedErr}
+	w := ajio.NewBufferedWriterAt(rec, 8)
+
+	_, err := w.WriteAt([]byte("abc"), 0)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, w.Flush(), expectedErr)
+	_, err = w.WriteAt([]byte("d"), 3)
+	assert.ErrorIs(t, err, expectedErr)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"math/bits"
+	"sync"
+)
+
+const (
+	minBufferClassBits = 6  // smallest size class is 64 bytes
+	maxBufferClassBits = 24 // largest size class is 16 MiB
+	numBufferClasses   = maxBufferClassBits - minBufferClassBits + 1
+)
+
+// DefaultBufferPool is a shared BufferPool that can be used when there is no need for a dedicated one.
+var DefaultBufferPool = NewBufferPool()
+
+// BufferPool is used to reuse byte slices and thus reduce the number of allocations.
+// Buffers are grouped into size classes that are powers of two between 64 bytes and 16 MiB.
+// Buffers requested that are larger than the biggest size class are allocated as normal and not pooled.
+// BufferPool is safe for concurrent use by multiple goroutines.
+type BufferPool struct {
+	classes [numBufferClasses]sync.Pool
+}
+
+// Create a new BufferPool.
+func NewBufferPool() *BufferPool {
+	p := &BufferPool{}
+	for i := range p.classes {
+		size := 1 << (i + minBufferClassBits)
+		p.classes[i].New = func() any {
+			b := make([]byte, size)
+			return &b
+		}
+	}
+	return p
+}
+
+// Get a buffer with a length of size bytes.
+// The capacity of the buffer may be larger than the requested size.
+// The contents of the buffer is not cleared.
+func (p *BufferPool) Get(size int) []byte {
+	idx := getBufferClass(size)
+	if idx < 0 {
+		return make([]byte, size)
+	}
+
+	bp := p.classes[idx].Get().(*[]byte)
+	return (*bp)[:size]
+}
+
+// Return the buffer to the pool so that it can be reused.
+// The buffer must not be used after it has been returned.
+func (p *BufferPool) Put(b []byte) {
+	idx := putBufferClass(cap(b))
+	if idx < 0 {
+		return
+	}
+
+	b = b[:cap(b)]
+	p.classes[idx].Put(&b)
+}
+
+// Return the index of the smallest size class that can hold size bytes or -1 if it is too big.
+func getBufferClass(size int) int {
+	if size <= 1<<minBufferClassBits {
+		return 0
+	}
+
+	idx := bits.Len(uint(size-1)) - minBufferClassBits
+	if idx >= numBufferClasses {
+		return -1
+	}
+	return idx
+}
+
+// Return the index of the largest size class that can be filled by a buffer with the capacity
+// or -1 if the capacity is too small or too big.
+func putBufferClass(capacity int) int {
+	if capacity < 1<<minBufferClassBits {
+		return -1
+	}
+
+	idx := bits.Len(uint(capacity)) - 1 - minBufferClassBits
+	if idx >= numBufferClasses {
+		return -1
+	}
+	return idx
+}
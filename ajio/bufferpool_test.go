@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferPool(t *testing.T) {
+	p := ajio.NewBufferPool()
+
+	b := p.Get(10)
+	assert.Len(t, b, 10)
+	assert.Equal(t, 64, cap(b))
+	p.Put(b)
+
+	b = p.Get(64)
+	assert.Len(t, b, 64)
+	assert.Equal(t, 64, cap(b))
+
+	b = p.Get(65)
+	assert.Len(t, b, 65)
+	assert.Equal(t, 128, cap(b))
+
+	b = p.Get(1000)
+	assert.Len(t, b, 1000)
+	assert.Equal(t, 1024, cap(b))
+
+	b = p.Get(0)
+	assert.Len(t, b, 0)
+
+	// Too big to be pooled
+	size := (16 << 20) + 1
+	b = p.Get(size)
+	assert.Len(t, b, size)
+	assert.Equal(t, size, cap(b))
+	p.Put(b)
+
+	// Too small to be pooled
+	p.Put(make([]byte, 10))
+
+	// A buffer with an odd capacity is placed in the largest class it can fill
+	p.Put(make([]byte, 100))
+	b = p.Get(64)
+	assert.GreaterOrEqual(t, cap(b), 64)
+}
+
+func TestBufferPoolConcurrent(t *testing.T) {
+	p := ajio.DefaultBufferPool
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(size int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				b := p.Get(size)
+				assert.Len(t, b, size)
+				for k := range b {
+					b[k] = byte(k)
+				}
+				p.Put(b)
+			}
+		}((i + 1) * 100)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,477 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// Encode writes the binary representation of v to w using the specified byte order.
+// It supports the same fixed-size data types as [binary.Write], however the layout of a struct
+// (or array) type is determined only once and cached which avoids most of the reflection
+// cost and allocations of repeatedly calling binary.Write with the same type.
+// Data types that are not fixed-size are passed on to binary.Write.
+func Encode(w io.Writer, order binary.ByteOrder, v any) error {
+	scratch, small := getCodecBuffer(8)
+	if b := encodeFast(scratch, order, v); b != nil {
+		_, err := w.Write(b)
+		putCodecBuffer(scratch, small)
+		return err
+	}
+	putCodecBuffer(scratch, small)
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		// binary.Write returns an error for a nil pointer but panics for a nil interface
+		if v == nil {
+			return errEncodeNil
+		}
+		return binary.Write(w, order, v)
+	}
+	c := codecFor(rv.Type())
+	if c == nil {
+		return binary.Write(w, order, v)
+	}
+
+	buf, small := getCodecBuffer(c.size)
+	defer putCodecBuffer(buf, small)
+
+	c.encode(buf, rv, order)
+	_, err := w.Write(buf)
+	return err
+}
+
+// Decode reads the binary representation from r into v using the specified byte order.
+// v must be a pointer to a fixed-size value or a slice of fixed-size values.
+// It supports the same data types as [binary.Read], however the layout of a struct
+// (or array) type is determined only once and cached which avoids most of the reflection
+// cost and allocations of repeatedly calling binary.Read with the same type.
+func Decode(r io.Reader, order binary.ByteOrder, v any) error {
+	if ok, err := decodeFast(r, order, v); ok {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return binary.Read(r, order, v)
+	}
+
+	elem := rv.Elem()
+	c := codecFor(elem.Type())
+	if c == nil {
+		return binary.Read(r, order, v)
+	}
+
+	buf, small := getCodecBuffer(c.size)
+	defer putCodecBuffer(buf, small)
+
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+	c.decode(buf, elem, order)
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Handle the most common scalar types without any reflection.
+func encodeFast(b []byte, order binary.ByteOrder, v any) []byte {
+	switch x := v.(type) {
+	case uint8:
+		b[0] = x
+		return b[:1]
+	case *uint8:
+		b[0] = *x
+		return b[:1]
+	case int8:
+		b[0] = byte(x)
+		return b[:1]
+	case *int8:
+		b[0] = byte(*x)
+		return b[:1]
+	case bool:
+		b[0] = boolToByte(x)
+		return b[:1]
+	case *bool:
+		b[0] = boolToByte(*x)
+		return b[:1]
+	case uint16:
+		order.PutUint16(b, x)
+		return b[:2]
+	case *uint16:
+		order.PutUint16(b, *x)
+		return b[:2]
+	case int16:
+		order.PutUint16(b, uint16(x))
+		return b[:2]
+	case *int16:
+		order.PutUint16(b, uint16(*x))
+		return b[:2]
+	case uint32:
+		order.PutUint32(b, x)
+		return b[:4]
+	case *uint32:
+		order.PutUint32(b, *x)
+		return b[:4]
+	case int32:
+		order.PutUint32(b, uint32(x))
+		return b[:4]
+	case *int32:
+		order.PutUint32(b, uint32(*x))
+		return b[:4]
+	case uint64:
+		order.PutUint64(b, x)
+		return b[:8]
+	case *uint64:
+		order.PutUint64(b, *x)
+		return b[:8]
+	case int64:
+		order.PutUint64(b, uint64(x))
+		return b[:8]
+	case *int64:
+		order.PutUint64(b, uint64(*x))
+		return b[:8]
+	}
+	return nil
+}
+
+// Handle the most common scalar types without any reflection.
+// Returns false if the type is not handled.
+func decodeFast(r io.Reader, order binary.ByteOrder, v any) (bool, error) {
+	var n int
+	switch v.(type) {
+	case *uint8, *int8, *bool:
+		n = 1
+	case *uint16, *int16:
+		n = 2
+	case *uint32, *int32:
+		n = 4
+	case *uint64, *int64:
+		n = 8
+	default:
+		return false, nil
+	}
+
+	b, small := getCodecBuffer(n)
+	defer putCodecBuffer(b, small)
+
+	if _, err := io.ReadFull(r, b); err != nil {
+		return true, err
+	}
+
+	switch x := v.(type) {
+	case *uint8:
+		*x = b[0]
+	case *int8:
+		*x = int8(b[0])
+	case *bool:
+		*x = b[0] != 0
+	case *uint16:
+		*x = order.Uint16(b)
+	case *int16:
+		*x = int16(order.Uint16(b))
+	case *uint32:
+		*x = order.Uint32(b)
+	case *int32:
+		*x = int32(order.Uint32(b))
+	case *uint64:
+		*x = order.Uint64(b)
+	case *int64:
+		*x = int64(order.Uint64(b))
+	}
+	return true, nil
+}
+
+// Small values are encoded using a buffer that is cheaper to obtain than going through the pool.
+const codecSmallSize = 64
+
+var codecSmallPool = sync.Pool{
+	New: func() any {
+		return new([codecSmallSize]byte)
+	},
+}
+
+// Return a buffer of the specified size. If small is not nil then the buffer was obtained from
+// the small buffer pool.
+func getCodecBuffer(size int) (buf []byte, small *[codecSmallSize]byte) {
+	if size <= codecSmallSize {
+		small = codecSmallPool.Get().(*[codecSmallSize]byte) //nolint:errcheck // only this type is stored
+		return small[:size], small
+	}
+	return DefaultBufferPool.Get(size), nil
+}
+
+// Return the buffer obtained from getCodecBuffer to the pool.
+func putCodecBuffer(buf []byte, small *[codecSmallSize]byte) {
+	if small != nil {
+		codecSmallPool.Put(small)
+		return
+	}
+	DefaultBufferPool.Put(buf)
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
+
+// codec describes how to encode and decode a fixed-size type.
+type codec struct {
+	size   int
+	encode func(b []byte, v reflect.Value, order binary.ByteOrder)
+	decode func(b []byte, v reflect.Value, order binary.ByteOrder)
+}
+
+var (
+	codecCache sync.Map // reflect.Type -> *codec (nil if the type is not supported)
+
+	errUnsupportedType = errors.New("unsupported type")
+	errEncodeNil       = errors.New("ajio: cannot encode a nil value")
+)
+
+// Return the cached codec for the type or nil if the type is not fixed-size.
+func codecFor(t reflect.Type) *codec {
+	if cached, ok := codecCache.Load(t); ok {
+		return cached.(*codec)
+	}
+
+	c, err := buildCodec(t)
+	if err != nil {
+		c = nil
+	}
+	codecCache.Store(t, c)
+	return c
+}
+
+func buildCodec(t reflect.Type) (*codec, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &codec{
+			size: 1,
+			encode: func(b []byte, v reflect.Value, _ binary.ByteOrder) {
+				b[0] = boolToByte(v.Bool())
+			},
+			decode: func(b []byte, v reflect.Value, _ binary.ByteOrder) {
+				v.SetBool(b[0] != 0)
+			},
+		}, nil
+
+	case reflect.Int8:
+		return &codec{
+			size: 1,
+			encode: func(b []byte, v reflect.Value, _ binary.ByteOrder) {
+				b[0] = byte(v.Int())
+			},
+			decode: func(b []byte, v reflect.Value, _ binary.ByteOrder) {
+				v.SetInt(int64(int8(b[0])))
+			},
+		}, nil
+
+	case reflect.Uint8:
+		return &codec{
+			size: 1,
+			encode: func(b []byte, v reflect.Value, _ binary.ByteOrder) {
+				b[0] = byte(v.Uint())
+			},
+			decode: func(b []byte, v reflect.Value, _ binary.ByteOrder) {
+				v.SetUint(uint64(b[0]))
+			},
+		}, nil
+
+	case reflect.Int16:
+		return &codec{
+			size: 2,
+			encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				order.PutUint16(b, uint16(v.Int()))
+			},
+			decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				v.SetInt(int64(int16(order.Uint16(b))))
+			},
+		}, nil
+
+	case reflect.Uint16:
+		return &codec{
+			size: 2,
+			encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				order.PutUint16(b, uint16(v.Uint()))
+			},
+			decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				v.SetUint(uint64(order.Uint16(b)))
+			},
+		}, nil
+
+	case reflect.Int32:
+		return &codec{
+			size: 4,
+			encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				order.PutUint32(b, uint32(v.Int()))
+			},
+			decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				v.SetInt(int64(int32(order.Uint32(b))))
+			},
+		}, nil
+
+	case reflect.Uint32:
+		return &codec{
+			size: 4,
+			encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				order.PutUint32(b, uint32(v.Uint()))
+			},
+			decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				v.SetUint(uint64(order.Uint32(b)))
+			},
+		}, nil
+
+	case reflect.Int64:
+		return &codec{
+			size: 8,
+			encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				order.PutUint64(b, uint64(v.Int()))
+			},
+			decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				v.SetInt(int64(order.Uint64(b)))
+			},
+		}, nil
+
+	case reflect.Uint64:
+		return &codec{
+			size: 8,
+			encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				order.PutUint64(b, v.Uint())
+			},
+			decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				v.SetUint(order.Uint64(b))
+			},
+		}, nil
+
+	case reflect.Float32:
+		return &codec{
+			size: 4,
+			encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				order.PutUint32(b, math.Float32bits(float32(v.Float())))
+			},
+			decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				v.SetFloat(float64(math.Float32frombits(order.Uint32(b))))
+			},
+		}, nil
+
+	case reflect.Float64:
+		return &codec{
+			size: 8,
+			encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				order.PutUint64(b, math.Float64bits(v.Float()))
+			},
+			decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+				v.SetFloat(math.Float64frombits(order.Uint64(b)))
+			},
+		}, nil
+
+	case reflect.Array:
+		return buildArrayCodec(t)
+
+	case reflect.Struct:
+		return buildStructCodec(t)
+	}
+
+	return nil, errUnsupportedType
+}
+
+func buildArrayCodec(t reflect.Type) (*codec, error) {
+	elem, err := buildCodec(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	count := t.Len()
+	return &codec{
+		size: elem.size * count,
+		encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+			for i := 0; i < count; i++ {
+				elem.encode(b[i*elem.size:], v.Index(i), order)
+			}
+		},
+		decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+			for i := 0; i < count; i++ {
+				elem.decode(b[i*elem.size:], v.Index(i), order)
+			}
+		},
+	}, nil
+}
+
+func buildStructCodec(t reflect.Type) (*codec, error) {
+	type field struct {
+		index  int
+		offset int
+		blank  bool
+		codec  *codec
+	}
+
+	fields := make([]field, 0, t.NumField())
+	size := 0
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		c, err := buildCodec(sf.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		blank := sf.Name == "_"
+		if !blank && !sf.IsExported() {
+			// Same as binary.Read, unexported fields can not be set
+			return nil, errUnsupportedType
+		}
+
+		fields = append(fields, field{index: i, offset: size, blank: blank, codec: c})
+		size += c.size
+	}
+
+	return &codec{
+		size: size,
+		encode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+			for _, f := range fields {
+				if f.blank {
+					// Blank fields are written as zeros
+					clear(b[f.offset : f.offset+f.codec.size])
+					continue
+				}
+				f.codec.encode(b[f.offset:], v.Field(f.index), order)
+			}
+		},
+		decode: func(b []byte, v reflect.Value, order binary.ByteOrder) {
+			for _, f := range fields {
+				if f.blank {
+					// Blank fields are skipped
+					continue
+				}
+				f.codec.decode(b[f.offset:], v.Field(f.index), order)
+			}
+		},
+	}, nil
+}
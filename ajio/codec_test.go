@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type codecHeader struct {
+	Magic   [4]byte
+	Version uint16
+	Flags   int16
+	Count   uint32
+	Offset  int64
+	Ratio   float32
+	Scale   float64
+	Valid   bool
+	_       [3]byte
+	Nested  codecNested
+	Small   int8
+}
+
+type codecNested struct {
+	A uint8
+	B [2]int32
+}
+
+func TestEncodeDecodeMatchesBinary(t *testing.T) {
+	h := codecHeader{
+		Magic:   [4]byte{'A', 'J', 'H', 'D'},
+		Version: 42,
+		Flags:   -2,
+		Count:   0xDEADBEEF,
+		Offset:  -1234567890123,
+		Ratio:   1.5,
+		Scale:   -0.25,
+		Valid:   true,
+		Nested:  codecNested{A: 7, B: [2]int32{-1, 1}},
+		Small:   -7,
+	}
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		var expected bytes.Buffer
+		require.NoError(t, binary.Write(&expected, order, &h))
+
+		var actual bytes.Buffer
+		require.NoError(t, ajio.Encode(&actual, order, &h))
+		assert.Equal(t, expected.Bytes(), actual.Bytes())
+
+		// Encode again to use the cached layout
+		actual.Reset()
+		require.NoError(t, ajio.Encode(&actual, order, h))
+		assert.Equal(t, expected.Bytes(), actual.Bytes())
+
+		var decoded codecHeader
+		require.NoError(t, ajio.Decode(&actual, order, &decoded))
+		assert.Equal(t, h, decoded)
+	}
+}
+
+func TestEncodeDecodeScalars(t *testing.T) {
+	var buf bytes.Buffer
+	order := binary.BigEndian
+
+	require.NoError(t, ajio.Encode(&buf, order, uint8(1)))
+	require.NoError(t, ajio.Encode(&buf, order, int8(-2)))
+	require.NoError(t, ajio.Encode(&buf, order, true))
+	require.NoError(t, ajio.Encode(&buf, order, uint16(3)))
+	require.NoError(t, ajio.Encode(&buf, order, int16(-4)))
+	require.NoError(t, ajio.Encode(&buf, order, uint32(5)))
+	require.NoError(t, ajio.Encode(&buf, order, int32(-6)))
+	require.NoError(t, ajio.Encode(&buf, order, uint64(7)))
+	require.NoError(t, ajio.Encode(&buf, order, int64(-8)))
+	require.NoError(t, ajio.Encode(&buf, order, float64(9.5)))
+	require.NoError(t, ajio.Encode(&buf, order, []uint16{10, 11}))
+
+	var expected bytes.Buffer
+	for _, v := range []any{uint8(1), int8(-2), true, uint16(3), int16(-4), uint32(5), int32(-6),
+		uint64(7), int64(-8), float64(9.5), []uint16{10, 11}} {
+		require.NoError(t, binary.Write(&expected, order, v))
+	}
+	assert.Equal(t, expected.Bytes(), buf.Bytes())
+
+	var u8 uint8
+	var i8 int8
+	var b bool
+	var u16 uint16
+	var i16 int16
+	var u32 uint32
+	var i32 int32
+	var u64 uint64
+	var i64 int64
+	var f64 float64
+	s := make([]uint16, 2)
+	for _, v := range []any{&u8, &i8, &b, &u16, &i16, &u32, &i32, &u64, &i64, &f64, s} {
+		require.NoError(t, ajio.Decode(&buf, order, v))
+	}
+
+	assert.Equal(t, uint8(1), u8)
+	assert.Equal(t, int8(-2), i8)
+	assert.True(t, b)
+	assert.Equal(t, uint16(3), u16)
+	assert.Equal(t, int16(-4), i16)
+	assert.Equal(t, uint32(5), u32)
+	assert.Equal(t, int32(-6), i32)
+	assert.Equal(t, uint64(7), u64)
+	assert.Equal(t, int64(-8), i64)
+	assert.Equal(t, 9.5, f64)
+	assert.Equal(t, []uint16{10, 11}, s)
+}
+
+func TestDecodeShortRead(t *testing.T) {
+	var h codecHeader
+	err := ajio.Decode(bytes.NewReader([]byte{1, 2, 3}), binary.LittleEndian, &h)
+	assert.Error(t, err)
+
+	var u32 uint32
+	err = ajio.Decode(bytes.NewReader([]byte{1, 2, 3}), binary.LittleEndian, &u32)
+	assert.Error(t, err)
+}
+
+func TestEncodeNil(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, ajio.Encode(&buf, binary.LittleEndian, nil))
+	assert.Error(t, ajio.Encode(&buf, binary.LittleEndian, (*codecHeader)(nil)))
+	assert.Equal(t, 0, buf.Len())
+}
+
+func BenchmarkDecode(b *testing.B) {
+	var buf bytes.Buffer
+	require.NoError(b, binary.Write(&buf, binary.LittleEndian, &codecHeader{}))
+	data := buf.Bytes()
+
+	b.Run("binary.Read", func(b *testing.B) {
+		var h codecHeader
+		for i := 0; i < b.N; i++ {
+			_ = binary.Read(bytes.NewReader(data), binary.LittleEndian, &h)
+		}
+	})
+
+	b.Run("ajio.Decode", func(b *testing.B) {
+		var h codecHeader
+		for i := 0; i < b.N; i++ {
+			_ = ajio.Decode(bytes.NewReader(data), binary.LittleEndian, &h)
+		}
+	})
+}
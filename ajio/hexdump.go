@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// HexDumpWriter passes data through to an underlying io.Writer while writing a `hexdump -C`
+// style dump (offset, hex and ASCII) of the same data to a debug io.Writer.
+type HexDumpWriter struct {
+	w      io.Writer
+	dumper io.WriteCloser
+}
+
+// Create a new HexDumpWriter that writes data to w and the hex dump of the data to out.
+// Close must be called to write the final partial line of the dump.
+func NewHexDumpWriter(w io.Writer, out io.Writer) *HexDumpWriter {
+	return &HexDumpWriter{
+		w:      w,
+		dumper: hex.Dumper(out),
+	}
+}
+
+// io.Writer.
+// Only the bytes that were successfully written to the underlying io.Writer are dumped.
+func (h *HexDumpWriter) Write(p []byte) (int, error) {
+	n, err := h.w.Write(p)
+	if n > 0 {
+		if _, dumpErr := h.dumper.Write(p[:n]); dumpErr != nil && err == nil {
+			err = fmt.Errorf("failed to write the hex dump. %w", dumpErr)
+		}
+	}
+	return n, err
+}
+
+// io.Closer.
+// Writes the final partial line of the dump. The underlying io.Writer is not closed.
+func (h *HexDumpWriter) Close() error {
+	return h.dumper.Close()
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexDumpWriter(t *testing.T) {
+	data := []byte("The quick brown fox jumped over the lazy dog!\x00\x01\x02")
+
+	var buf bytes.Buffer
+	var dump bytes.Buffer
+	w := ajio.NewHexDumpWriter(&buf, &dump)
+
+	_, err := w.Write(data[:10])
+	require.NoError(t, err)
+	_, err = w.Write(data[10:])
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, data, buf.Bytes())
+	assert.Equal(t, hex.Dump(data), dump.String())
+	assert.Contains(t, dump.String(), "00000000  54 68 65 20 71 75 69 63  6b 20 62 72 6f 77 6e 20  |The quick brown |")
+}
+
+func TestHexDumpWriterPartialWrite(t *testing.T) {
+	var dump bytes.Buffer
+	w := ajio.NewHexDumpWriter(ajio.NewLimitWriter(&bytes.Buffer{}, 4), &dump)
+
+	n, err := w.Write([]byte("abcdefgh"))
+	assert.ErrorIs(t, err, ajio.ErrWriteLimitExceeded)
+	assert.Equal(t, 4, n)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, hex.Dump([]byte("abcd")), dump.String())
+}
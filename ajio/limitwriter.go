@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"errors"
+	"io"
+)
+
+// The LimitWriter has reached its limit and could not write all of the data.
+var ErrWriteLimitExceeded = errors.New("ajio: write limit exceeded")
+
+// LimitWriter writes to an underlying io.Writer but stops with [ErrWriteLimitExceeded] once the limit has been reached.
+type LimitWriter struct {
+	w         io.Writer
+	remaining int64
+}
+
+// Create a new LimitWriter that allows at most n bytes to be written to w.
+func NewLimitWriter(w io.Writer, n int64) *LimitWriter {
+	return &LimitWriter{
+		w:         w,
+		remaining: n,
+	}
+}
+
+// io.Writer.
+// If p is larger than the number of bytes remaining then only the remaining bytes are written
+// and [ErrWriteLimitExceeded] is returned.
+func (l *LimitWriter) Write(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, ErrWriteLimitExceeded
+	}
+
+	exceeded := false
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+		exceeded = true
+	}
+
+	n, err := l.w.Write(p)
+	l.remaining -= int64(n)
+	if err == nil && exceeded {
+		err = ErrWriteLimitExceeded
+	}
+	return n, err
+}
+
+// Return the number of bytes that may still be written.
+func (l *LimitWriter) Remaining() int64 {
+	return max(l.remaining, 0)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := ajio.NewLimitWriter(&buf, 10)
+	assert.Equal(t, int64(10), w.Remaining())
+
+	n, err := w.Write([]byte("The quick"))
+	require.NoError(t, err)
+	assert.Equal(t, 9, n)
+	assert.Equal(t, int64(1), w.Remaining())
+
+	n, err = w.Write([]byte(" brown"))
+	assert.ErrorIs(t, err, ajio.ErrWriteLimitExceeded)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, int64(0), w.Remaining())
+
+	n, err = w.Write([]byte("fox"))
+	assert.ErrorIs(t, err, ajio.ErrWriteLimitExceeded)
+	assert.Equal(t, 0, n)
+
+	assert.Equal(t, "The quick ", buf.String())
+}
+
+func TestLimitWriterWithCopy(t *testing.T) {
+	var buf bytes.Buffer
+	w := ajio.NewLimitWriter(&buf, 5)
+
+	n, err := io.Copy(w, strings.NewReader("The quick brown fox"))
+	assert.ErrorIs(t, err, ajio.ErrWriteLimitExceeded)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, "The q", buf.String())
+
+	w = ajio.NewLimitWriter(&buf, 0)
+	n, err = io.Copy(w, strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build linux
+// +build linux
+
+package mmapfile_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio/mmapfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenFallbackWhenMmapFails(t *testing.T) {
+	// sysfs attributes report a size but can't be memory-mapped
+	path := "/sys/kernel/mm/transparent_hugepage/enabled"
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Skipf("the file %q is not available. %v", path, err)
+	}
+
+	f, err := mmapfile.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	assert.False(t, f.Mapped())
+
+	buffer := make([]byte, len(expected))
+	// The reported size is larger than the contents and so io.EOF may be returned
+	n, err := f.ReadAt(buffer, 0)
+	if err != nil {
+		require.ErrorIs(t, err, io.EOF)
+	}
+	assert.Equal(t, string(expected), string(buffer[:n]))
+}
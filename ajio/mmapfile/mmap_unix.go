@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package mmapfile
+
+import (
+	"fmt"
+	"math"
+	"syscall"
+)
+
+// Memory-map the file or fall back to regular reads when the mapping fails.
+func (f *File) mmap() error {
+	if f.size == 0 {
+		// Mapping an empty file is not allowed
+		f.mapped = true
+		return nil
+	}
+	if f.size > math.MaxInt {
+		return fmt.Errorf("mmapfile: size %d exceeds the maximum that can be mapped", f.size)
+	}
+
+	data, err := syscall.Mmap(int(f.of.Fd()), 0, int(f.size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		// Not every file can be mapped (e.g. files on some network or special filesystems)
+		return nil
+	}
+
+	f.data = data
+	f.mapped = true
+	return nil
+}
+
+func (f *File) munmap() error {
+	if !f.mapped || f.data == nil {
+		return nil
+	}
+	return syscall.Munmap(f.data)
+}
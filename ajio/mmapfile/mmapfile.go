@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mmapfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+)
+
+// The File has already been closed.
+var ErrClosed = errors.New("mmapfile: file already closed")
+
+// File provides read-only access to the contents of a file that has been memory-mapped.
+// Implements the following interfaces: io.ReaderAt, io.Closer.
+type File struct {
+	of     *os.File
+	data   []byte
+	size   int64
+	mapped bool
+	closed bool
+}
+
+// Open the file for reading and memory-map the contents.
+func Open(path string) (*File, error) {
+	of, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the file %q. %w", path, err)
+	}
+
+	info, err := of.Stat()
+	if err != nil {
+		of.Close()
+		return nil, fmt.Errorf("failed to do Stat() on the file %q. %w", path, err)
+	}
+
+	size := info.Size()
+	if _, err := safe.Uint64ToInt(uint64(size)); err != nil {
+		of.Close()
+		return nil, fmt.Errorf("the file %q is too big to be memory-mapped. %w", path, err)
+	}
+
+	f := &File{
+		of:   of,
+		size: size,
+	}
+
+	if err := f.mmap(); err != nil {
+		of.Close()
+		return nil, fmt.Errorf("failed to memory-map the file %q. %w", path, err)
+	}
+
+	return f, nil
+}
+
+// Close the file and release the memory-mapped region.
+// Any byte slice returned by Bytes must not be used after Close has been called.
+func (f *File) Close() error {
+	if f.closed {
+		return ErrClosed
+	}
+	f.closed = true
+
+	err := f.munmap()
+	f.data = nil
+	if cerr := f.of.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Path of the file.
+func (f *File) Name() string {
+	return f.of.Name()
+}
+
+// Return the size of the file in bytes.
+func (f *File) Size() int64 {
+	return f.size
+}
+
+// Return true if the file's contents has been memory-mapped or false if the fallback of regular reads is being used.
+func (f *File) Mapped() bool {
+	return f.mapped
+}
+
+// io.ReaderAt.
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	if f.closed {
+		return 0, ErrClosed
+	}
+
+	if !f.mapped {
+		return f.of.ReadAt(p, off)
+	}
+
+	if off < 0 {
+		return 0, fmt.Errorf("mmapfile: invalid offset %d", off)
+	}
+	if off >= f.size {
+		return 0, io.EOF
+	}
+
+	n := copy(p, f.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Return the entire contents of the file.
+// When the file is memory-mapped no copy is made and the returned slice must not be modified.
+// When the fallback is being used the file is read into memory on the first call.
+func (f *File) Bytes() ([]byte, error) {
+	if f.closed {
+		return nil, ErrClosed
+	}
+
+	if f.data == nil && f.size > 0 {
+		data := make([]byte, f.size)
+		if _, err := f.of.ReadAt(data, 0); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read the file %q. %w", f.Name(), err)
+		}
+		f.data = data
+	}
+
+	return f.data, nil
+}
+
+// Create a new io.SectionReader that can be used to read the entire file sequentially.
+func (f *File) NewReader() *io.SectionReader {
+	return io.NewSectionReader(f, 0, f.size)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mmapfile_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio/mmapfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	expected := "The quick brown fox jumped over the lazy dog!"
+	path := filepath.Join(t.TempDir(), "unit-testing")
+	require.NoError(t, os.WriteFile(path, []byte(expected), 0o644))
+
+	f, err := mmapfile.Open(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, path, f.Name())
+	assert.Equal(t, int64(len(expected)), f.Size())
+
+	data, err := f.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+
+	buffer := make([]byte, 5)
+	n, err := f.ReadAt(buffer, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "quick", string(buffer))
+
+	n, err = f.ReadAt(buffer, int64(len(expected)-3))
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, "og!", string(buffer[:n]))
+
+	_, err = f.ReadAt(buffer, int64(len(expected)))
+	assert.ErrorIs(t, err, io.EOF)
+
+	all, err := io.ReadAll(f.NewReader())
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(all))
+
+	require.NoError(t, f.Close())
+	assert.ErrorIs(t, f.Close(), mmapfile.ErrClosed)
+	_, err = f.ReadAt(buffer, 0)
+	assert.ErrorIs(t, err, mmapfile.ErrClosed)
+	_, err = f.Bytes()
+	assert.ErrorIs(t, err, mmapfile.ErrClosed)
+}
+
+func TestOpenEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unit-testing")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+
+	f, err := mmapfile.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	data, err := f.Bytes()
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	_, err = f.ReadAt(make([]byte, 1), 0)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestOpenMissingFile(t *testing.T) {
+	_, err := mmapfile.Open(filepath.Join(t.TempDir(), "does-not-exist"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
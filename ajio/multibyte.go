@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// MultiByteReader is able to read a slice of bytes as well as a single byte.
+// bufio.NewReader implements this interface.
+type MultiByteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// MultiByteScanner is a MultiByteReader that can also unread the last byte read.
+// bufio.NewReader implements this interface.
+type MultiByteScanner interface {
+	io.Reader
+	io.ByteScanner
+}
+
+// MultiByteReaderSeeker is a MultiByteScanner that can also seek.
+// Peek and Discard are provided to inspect or skip buffered data without having to seek.
+type MultiByteReaderSeeker interface {
+	MultiByteScanner
+	io.Seeker
+	Peek(n int) ([]byte, error)
+	Discard(n int) (int, error)
+}
+
+// Create a new MultiByteReader from the io.Reader.
+// If r already implements MultiByteReader then it is returned as is, otherwise reads are buffered.
+func NewMultiByteReader(r io.Reader) MultiByteReader {
+	if mbr, ok := r.(MultiByteReader); ok {
+		return mbr
+	}
+	return bufio.NewReader(r)
+}
+
+// Create a new MultiByteScanner from the io.Reader.
+// If r already implements MultiByteScanner then it is returned as is, otherwise reads are buffered.
+func NewMultiByteScanner(r io.Reader) MultiByteScanner {
+	if mbs, ok := r.(MultiByteScanner); ok {
+		return mbs
+	}
+	return bufio.NewReader(r)
+}
+
+// Create a new MultiByteReaderSeeker that buffers reads from the io.ReadSeeker.
+// If rs already implements MultiByteReaderSeeker then it is returned as is.
+func NewMultiByteReaderSeeker(rs io.ReadSeeker) MultiByteReaderSeeker {
+	if mbrs, ok := rs.(MultiByteReaderSeeker); ok {
+		return mbrs
+	}
+
+	return &wrappedBufIOReadSeeker{
+		rs: rs,
+		br: bufio.NewReader(rs),
+	}
+}
+
+// MultiByteWriter is able to write a slice of bytes as well as a single byte.
+// bufio.NewWriter implements this interface.
+type MultiByteWriter interface {
+	io.Writer
+	io.ByteWriter
+}
+
+// MultiByteWriterSeeker is a MultiByteWriter that can also seek.
+// Because writes are buffered, Flush must be called to ensure all data has been written
+// to the underlying io.WriteSeeker.
+type MultiByteWriterSeeker interface {
+	MultiByteWriter
+	io.Seeker
+	Flush() error
+}
+
+// Create a new MultiByteWriterSeeker that buffers writes to the io.WriteSeeker.
+// If ws already implements MultiByteWriterSeeker then it is returned as is.
+func NewMultiByteWriterSeeker(ws io.WriteSeeker) MultiByteWriterSeeker {
+	if mbws, ok := ws.(MultiByteWriterSeeker); ok {
+		return mbws
+	}
+
+	return &wrappedBufIOWriteSeeker{
+		ws: ws,
+		bw: bufio.NewWriter(ws),
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+type wrappedBufIOReadSeeker struct {
+	rs io.ReadSeeker
+	br *bufio.Reader
+}
+
+// io.Reader.
+func (r *wrappedBufIOReadSeeker) Read(p []byte) (int, error) {
+	return r.br.Read(p)
+}
+
+// io.ByteReader.
+func (r *wrappedBufIOReadSeeker) ReadByte() (byte, error) {
+	return r.br.ReadByte()
+}
+
+// io.ByteScanner.
+// Only the last byte read can be unread and not after a call to Seek.
+func (r *wrappedBufIOReadSeeker) UnreadByte() error {
+	return r.br.UnreadByte()
+}
+
+// io.Seeker.
+// Relative seeks take into account the data that has been buffered but not yet read.
+// Forward relative seeks that stay within the buffered data are done by discarding from
+// the buffer, otherwise the buffer is reset after the seek has been performed.
+func (r *wrappedBufIOReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	if whence == io.SeekCurrent {
+		buffered := int64(r.br.Buffered())
+		if offset >= 0 && offset <= buffered {
+			return r.discard(int(offset))
+		}
+
+		// The underlying io.ReadSeeker is ahead by the buffered amount
+		offset -= buffered
+	}
+
+	pos, err := r.rs.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+
+	r.br.Reset(r.rs)
+	return pos, nil
+}
+
+// Skip n buffered bytes and return the resulting position.
+func (r *wrappedBufIOReadSeeker) discard(n int) (int64, error) {
+	if _, err := r.br.Discard(n); err != nil {
+		return 0, err
+	}
+
+	pos, err := r.rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	return pos - int64(r.br.Buffered()), nil
+}
+
+// Peek returns the next n bytes without advancing the reader.
+// See [bufio.Reader.Peek].
+func (r *wrappedBufIOReadSeeker) Peek(n int) ([]byte, error) {
+	return r.br.Peek(n)
+}
+
+// Discard skips the next n bytes and returns the number of bytes discarded.
+// See [bufio.Reader.Discard].
+func (r *wrappedBufIOReadSeeker) Discard(n int) (int, error) {
+	return r.br.Discard(n)
+}
+
+//-----------------------------------------------------------------------------
+
+type wrappedBufIOWriteSeeker struct {
+	ws io.WriteSeeker
+	bw *bufio.Writer
+}
+
+// io.Writer.
+func (w *wrappedBufIOWriteSeeker) Write(p []byte) (int, error) {
+	return w.bw.Write(p)
+}
+
+// io.ByteWriter.
+func (w *wrappedBufIOWriteSeeker) WriteByte(c byte) error {
+	return w.bw.WriteByte(c)
+}
+
+// io.Seeker.
+// Any buffered data is first flushed to the underlying io.WriteSeeker before the seek is performed
+// and then the buffer is reset.
+func (w *wrappedBufIOWriteSeeker) Seek(offset int64, whence int) (int64, error) {
+	if err := w.bw.Flush(); err != nil {
+		return 0, fmt.Errorf("failed to flush the buffered data before seeking. %w", err)
+	}
+
+	pos, err := w.ws.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+
+	w.bw.Reset(w.ws)
+	return pos, nil
+}
+
+// Flush writes any buffered data to the underlying io.WriteSeeker.
+func (w *wrappedBufIOWriteSeeker) Flush() error {
+	return w.bw.Flush()
+}
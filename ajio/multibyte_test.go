@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMultiByteReader(t *testing.T) {
+	r := ajio.NewMultiByteReader(io.LimitReader(bytes.NewReader([]byte("ab")), 2))
+	b, err := r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte('a'), b)
+
+	// Already a MultiByteReader
+	br := bytes.NewReader([]byte("ab"))
+	assert.Same(t, br, ajio.NewMultiByteReader(br))
+}
+
+func TestMultiByteReaderSeekerPeekAndDiscard(t *testing.T) {
+	r := ajio.NewMultiByteReaderSeeker(bytes.NewReader([]byte("\x89PNG\r\nThe rest")))
+
+	magic, err := r.Peek(4)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("\x89PNG"), magic)
+
+	// Peek does not advance
+	b, err := r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x89), b)
+
+	n, err := r.Discard(5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "The rest", string(rest))
+
+	pos, err := r.Seek(1, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pos)
+
+	peek, err := r.Peek(3)
+	require.NoError(t, err)
+	assert.Equal(t, "PNG", string(peek))
+
+	_, err = r.Discard(100)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestMultiByteReaderSeekerRelativeSeek(t *testing.T) {
+	data := make([]byte, 10000)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	r := ajio.NewMultiByteReaderSeeker(bytes.NewReader(data))
+
+	expect := func(pos int64) {
+		t.Helper()
+		b, err := r.ReadByte()
+		require.NoError(t, err)
+		assert.Equal(t, data[pos], b)
+	}
+
+	expect(0)
+
+	// Small forward seek within the buffer
+	pos, err := r.Seek(9, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), pos)
+	expect(10)
+
+	// Backwards relative seek
+	pos, err = r.Seek(-5, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), pos)
+	expect(6)
+
+	// Forward seek past the buffered data
+	pos, err = r.Seek(8000, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8007), pos)
+	expect(8007)
+
+	// Report the current position
+	pos, err = r.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8008), pos)
+	expect(8008)
+}
+
+func TestMultiByteScannerUnreadByte(t *testing.T) {
+	scanners := map[string]ajio.MultiByteScanner{
+		"scanner": ajio.NewMultiByteScanner(io.LimitReader(bytes.NewReader([]byte("xy")), 2)),
+		"seeker":  ajio.NewMultiByteReaderSeeker(bytes.NewReader([]byte("xy"))),
+	}
+
+	for name, s := range scanners {
+		t.Run(name, func(t *testing.T) {
+			b, err := s.ReadByte()
+			require.NoError(t, err)
+			assert.Equal(t, byte('x'), b)
+
+			require.NoError(t, s.UnreadByte())
+			b, err = s.ReadByte()
+			require.NoError(t, err)
+			assert.Equal(t, byte('x'), b)
+
+			b, err = s.ReadByte()
+			require.NoError(t, err)
+			assert.Equal(t, byte('y'), b)
+		})
+	}
+}
+
+func TestMultiByteWriterSeeker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.bin")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := ajio.NewMultiByteWriterSeeker(f)
+
+	_, err = w.Write([]byte("Hello"))
+	require.NoError(t, err)
+	require.NoError(t, w.WriteByte(' '))
+	_, err = w.Write([]byte("world"))
+	require.NoError(t, err)
+
+	// Seeking flushes the buffer
+	pos, err := w.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), pos)
+
+	require.NoError(t, w.WriteByte('J'))
+
+	pos, err = w.Seek(5, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), pos)
+	_, err = w.Write([]byte("W"))
+	require.NoError(t, err)
+
+	pos, err = w.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), pos)
+	require.NoError(t, w.WriteByte('!'))
+
+	require.NoError(t, w.Flush())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Jello World!", string(data))
+}
+
+func TestNewMultiByteWriterSeekerReturnsExisting(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "test.bin"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := ajio.NewMultiByteWriterSeeker(f)
+	assert.Same(t, w, ajio.NewMultiByteWriterSeeker(w))
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// MultiFileReader presents several files as one contiguous stream.
+// Files are only opened once data is read from them and remain open until Close is called.
+// Implements the following interfaces: io.Reader, io.ReaderAt, io.Seeker, io.Closer.
+type MultiFileReader struct {
+	paths  []string
+	starts []int64 // the offset at which each file starts
+	size   int64
+	offset int64
+
+	mu     sync.Mutex
+	files  []*os.File
+	closed bool
+}
+
+// Create a new MultiFileReader that will read the files in the order given.
+// The size of each file is determined upfront and the files should not change while being read.
+func NewMultiFileReader(paths ...string) (*MultiFileReader, error) {
+	r := &MultiFileReader{
+		paths:  paths,
+		starts: make([]int64, len(paths)),
+		files:  make([]*os.File, len(paths)),
+	}
+
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the multi file reader. %w", err)
+		}
+		r.starts[i] = r.size
+		r.size += info.Size()
+	}
+
+	return r, nil
+}
+
+// io.Reader.
+func (r *MultiFileReader) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.offset)
+	r.offset += int64(n)
+	if n > 0 && errors.Is(err, io.EOF) {
+		err = nil
+	}
+	return n, err
+}
+
+// io.ReaderAt.
+func (r *MultiFileReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errOffset
+	}
+
+	total := 0
+	for total < len(p) {
+		pos := off + int64(total)
+		if pos >= r.size {
+			return total, io.EOF
+		}
+
+		idx := r.fileIndex(pos)
+		f, err := r.file(idx)
+		if err != nil {
+			return total, err
+		}
+
+		end := r.size
+		if idx+1 < len(r.starts) {
+			end = r.starts[idx+1]
+		}
+		chunk := p[total:]
+		if int64(len(chunk)) > end-pos {
+			chunk = chunk[:end-pos]
+		}
+
+		n, err := f.ReadAt(chunk, pos-r.starts[idx])
+		total += n
+		if err != nil && !errors.Is(err, io.EOF) {
+			return total, err
+		}
+		if n < len(chunk) {
+			return total, io.ErrUnexpectedEOF
+		}
+	}
+
+	return total, nil
+}
+
+// io.Seeker.
+func (r *MultiFileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	default:
+		return 0, errWhence
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, errOffset
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+// Return the combined size in bytes of all the files.
+func (r *MultiFileReader) Size() int64 {
+	return r.size
+}
+
+// Close all of the files that have been opened.
+func (r *MultiFileReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	var errs []error
+	for i, f := range r.files {
+		if f != nil {
+			errs = append(errs, f.Close())
+			r.files[i] = nil
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Return the index of the file that contains the offset.
+func (r *MultiFileReader) fileIndex(offset int64) int {
+	// Find the last file that starts at or before the offset (skipping empty files)
+	return sort.Search(len(r.starts), func(i int) bool {
+		return r.starts[i] > offset
+	}) - 1
+}
+
+// Return the opened file at the index.
+func (r *MultiFileReader) file(idx int) (*os.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil, os.ErrClosed
+	}
+
+	if r.files[idx] == nil {
+		f, err := os.Open(r.paths[idx])
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the file %q. %w", r.paths[idx], err)
+		}
+		r.files[idx] = f
+	}
+
+	return r.files[idx], nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiFileReader(t *testing.T) {
+	dir := t.TempDir()
+	parts := []string{"The quick ", "", "brown fox ", "jumped over the lazy dog!"}
+	paths := make([]string, len(parts))
+	for i, part := range parts {
+		paths[i] = filepath.Join(dir, "part"+string(rune('a'+i)))
+		require.NoError(t, os.WriteFile(paths[i], []byte(part), 0o644))
+	}
+	expected := "The quick brown fox jumped over the lazy dog!"
+
+	r, err := ajio.NewMultiFileReader(paths...)
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Equal(t, int64(len(expected)), r.Size())
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+
+	// ReadAt across file boundaries
+	buffer := make([]byte, 9)
+	n, err := r.ReadAt(buffer, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 9, n)
+	assert.Equal(t, "ick brown", string(buffer))
+
+	n, err = r.ReadAt(buffer, int64(len(expected)-4))
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, "dog!", string(buffer[:n]))
+
+	// Seek
+	pos, err := r.Seek(-4, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(expected)-4), pos)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "dog!", string(data))
+
+	pos, err = r.Seek(10, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), pos)
+	pos, err = r.Seek(6, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(16), pos)
+	n, err = r.Read(buffer[:3])
+	require.NoError(t, err)
+	assert.Equal(t, "fox", string(buffer[:n]))
+
+	_, err = r.Seek(-1, io.SeekStart)
+	assert.Error(t, err)
+
+	require.NoError(t, r.Close())
+	_, err = r.ReadAt(buffer, 0)
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestMultiFileReaderMissingFile(t *testing.T) {
+	_, err := ajio.NewMultiFileReader(filepath.Join(t.TempDir(), "does-not-exist"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"io"
+	"time"
+)
+
+// DefaultProgressInterval is the default minimum amount of time between progress reports.
+const DefaultProgressInterval = 500 * time.Millisecond
+
+// Progress describes how far along a transfer is.
+type Progress struct {
+	Done    int64         // Number of bytes transferred so far
+	Total   int64         // Total number of bytes expected or 0 if not known
+	Elapsed time.Duration // Time since the first byte was transferred
+	Rate    float64       // Average number of bytes per second
+	ETA     time.Duration // Estimated time remaining or -1 if it can not be determined
+}
+
+// Return the percentage (0 to 100) of the transfer that has been completed or -1 if the total is not known.
+func (p Progress) Percent() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+	return float64(p.Done) / float64(p.Total) * 100.0
+}
+
+// ProgressFunc is called to report progress.
+type ProgressFunc func(p Progress)
+
+// ProgressReader reports on the progress made while reading from an io.Reader.
+type ProgressReader struct {
+	rd io.Reader
+	progressTracker
+}
+
+// Create a new ProgressReader that will call fn at most every [DefaultProgressInterval] while reading from rd.
+// total is the expected number of bytes to be read or 0 if not known.
+// fn is always called once the total has been reached or when the end of rd has been reached.
+func NewProgressReader(rd io.Reader, total int64, fn ProgressFunc) *ProgressReader {
+	return &ProgressReader{
+		rd:              rd,
+		progressTracker: newProgressTracker(total, fn),
+	}
+}
+
+// io.Reader.
+func (r *ProgressReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	r.update(n, err == io.EOF)
+	return n, err
+}
+
+// ProgressWriter reports on the progress made while writing to an io.Writer.
+type ProgressWriter struct {
+	wd io.Writer
+	progressTracker
+}
+
+// Create a new ProgressWriter that will call fn at most every [DefaultProgressInterval] while writing to wd.
+// total is the expected number of bytes to be written or 0 if not known.
+// fn is always called once the total has been reached.
+func NewProgressWriter(wd io.Writer, total int64, fn ProgressFunc) *ProgressWriter {
+	return &ProgressWriter{
+		wd:              wd,
+		progressTracker: newProgressTracker(total, fn),
+	}
+}
+
+// io.Writer.
+func (w *ProgressWriter) Write(p []byte) (int, error) {
+	n, err := w.wd.Write(p)
+	w.update(n, false)
+	return n, err
+}
+
+//-----------------------------------------------------------------------------
+
+type progressTracker struct {
+	fn       ProgressFunc
+	interval time.Duration
+	done     int64
+	total    int64
+	start    time.Time
+	last     time.Time
+	finished bool
+}
+
+func newProgressTracker(total int64, fn ProgressFunc) progressTracker {
+	return progressTracker{
+		fn:       fn,
+		interval: DefaultProgressInterval,
+		total:    total,
+	}
+}
+
+// Set the minimum amount of time between progress reports.
+// An interval of 0 will report progress on every call.
+func (t *progressTracker) SetInterval(interval time.Duration) {
+	t.interval = interval
+}
+
+// Return the progress made so far.
+func (t *progressTracker) Progress() Progress {
+	return t.progress(time.Now())
+}
+
+func (t *progressTracker) update(n int, eof bool) {
+	now := time.Now()
+	if t.start.IsZero() {
+		t.start = now
+	}
+	if n > 0 {
+		t.done += int64(n)
+	}
+
+	if t.fn == nil || t.finished {
+		return
+	}
+
+	reachedEnd := eof || ((t.total > 0) && (t.done >= t.total))
+	if !reachedEnd && (now.Sub(t.last) < t.interval) {
+		return
+	}
+
+	t.last = now
+	t.finished = reachedEnd
+	t.fn(t.progress(now))
+}
+
+func (t *progressTracker) progress(now time.Time) Progress {
+	p := Progress{
+		Done:  t.done,
+		Total: t.total,
+		ETA:   -1,
+	}
+	if t.start.IsZero() {
+		return p
+	}
+
+	p.Elapsed = now.Sub(t.start)
+	if p.Elapsed > 0 {
+		p.Rate = float64(t.done) / p.Elapsed.Seconds()
+	}
+
+	if t.total > 0 {
+		remaining := t.total - t.done
+		if remaining <= 0 {
+			p.ETA = 0
+		} else if p.Rate > 0 {
+			p.ETA = time.Duration(float64(remaining) / p.Rate * float64(time.Second))
+		}
+	}
+
+	return p
+}
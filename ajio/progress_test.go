@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressReader(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+
+	var reports []ajio.Progress
+	r := ajio.NewProgressReader(strings.NewReader(text), int64(len(text)), func(p ajio.Progress) {
+		reports = append(reports, p)
+	})
+	r.SetInterval(0)
+
+	assert.Equal(t, time.Duration(-1), r.Progress().ETA)
+
+	buffer := make([]byte, 5)
+	_, err := r.Read(buffer)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, int64(5), reports[0].Done)
+	assert.Equal(t, int64(len(text)), reports[0].Total)
+	assert.InDelta(t, float64(5)/float64(len(text))*100.0, reports[0].Percent(), 0.001)
+
+	time.Sleep(10 * time.Millisecond)
+	_, err = io.ReadAll(r)
+	require.NoError(t, err)
+
+	last := reports[len(reports)-1]
+	assert.Equal(t, int64(len(text)), last.Done)
+	assert.InDelta(t, 100.0, last.Percent(), 0.001)
+	assert.Equal(t, time.Duration(0), last.ETA)
+	assert.Greater(t, last.Rate, 0.0)
+	assert.GreaterOrEqual(t, last.Elapsed, 10*time.Millisecond)
+
+	// No more reports after the end has been reached
+	count := len(reports)
+	_, err = r.Read(buffer)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Len(t, reports, count)
+}
+
+func TestProgressReaderUnknownTotal(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+
+	var reports []ajio.Progress
+	r := ajio.NewProgressReader(strings.NewReader(text), 0, func(p ajio.Progress) {
+		reports = append(reports, p)
+	})
+
+	_, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	// Reported at least once for the first read and once at the end
+	require.GreaterOrEqual(t, len(reports), 2)
+	last := reports[len(reports)-1]
+	assert.Equal(t, int64(len(text)), last.Done)
+	assert.Equal(t, float64(-1), last.Percent())
+	assert.Equal(t, time.Duration(-1), last.ETA)
+}
+
+func TestProgressWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	var reports []ajio.Progress
+	w := ajio.NewProgressWriter(&buf, 10, func(p ajio.Progress) {
+		reports = append(reports, p)
+	})
+	w.SetInterval(time.Hour)
+
+	_, err := w.Write([]byte("01234"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("56"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("789"))
+	require.NoError(t, err)
+
+	require.Len(t, reports, 2)
+	assert.Equal(t, int64(5), reports[0].Done)
+	assert.Equal(t, int64(10), reports[1].Done)
+	assert.Equal(t, int64(10), w.Progress().Done)
+	assert.Equal(t, "0123456789", buf.String())
+}
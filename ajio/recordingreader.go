@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"bytes"
+	"io"
+)
+
+// RecordingReader captures all the bytes read from an underlying io.Reader so that they can be
+// inspected or replayed afterwards. For example to include exactly what was parsed in an error report.
+type RecordingReader struct {
+	rd        io.Reader
+	buf       []byte
+	limit     int // maximum number of bytes kept or 0 for no limit
+	truncated bool
+}
+
+// Create a new RecordingReader that captures all the bytes read from rd.
+func NewRecordingReader(rd io.Reader) *RecordingReader {
+	return &RecordingReader{rd: rd}
+}
+
+// Create a new RecordingReader that captures at most limit bytes read from rd.
+// Bytes read beyond the limit are passed through but are not captured.
+func NewRecordingReaderWithLimit(rd io.Reader, limit int) *RecordingReader {
+	return &RecordingReader{rd: rd, limit: max(limit, 0)}
+}
+
+// io.Reader.
+func (r *RecordingReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	if n > 0 {
+		r.record(p[:n])
+	}
+	return n, err
+}
+
+// Return the captured bytes.
+// The returned slice is only valid until the next Read or Reset.
+func (r *RecordingReader) Bytes() []byte {
+	return r.buf
+}
+
+// Return true if some bytes were not captured because the limit was reached.
+func (r *RecordingReader) Truncated() bool {
+	return r.truncated
+}
+
+// Return a new io.Reader over a copy of the captured bytes.
+func (r *RecordingReader) Replay() io.Reader {
+	return bytes.NewReader(bytes.Clone(r.buf))
+}
+
+// Discard the captured bytes.
+func (r *RecordingReader) Reset() {
+	r.buf = r.buf[:0]
+	r.truncated = false
+}
+
+func (r *RecordingReader) record(p []byte) {
+	if r.limit > 0 {
+		remaining := r.limit - len(r.buf)
+		if len(p) > remaining {
+			p = p[:remaining]
+			r.truncated = true
+		}
+	}
+	r.buf = append(r.buf, p...)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingReader(t *testing.T) {
+	text := "The quick brown fox"
+	r := ajio.NewRecordingReader(iotest.HalfReader(strings.NewReader(text)))
+
+	p := make([]byte, 9)
+	_, err := io.ReadFull(r, p)
+	require.NoError(t, err)
+	assert.Equal(t, "The quick", string(r.Bytes()))
+
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, " brown fox", string(rest))
+	assert.Equal(t, text, string(r.Bytes()))
+	assert.False(t, r.Truncated())
+
+	replayed, err := io.ReadAll(r.Replay())
+	require.NoError(t, err)
+	assert.Equal(t, text, string(replayed))
+
+	// Replay again
+	replayed, err = io.ReadAll(r.Replay())
+	require.NoError(t, err)
+	assert.Equal(t, text, string(replayed))
+
+	r.Reset()
+	assert.Empty(t, r.Bytes())
+}
+
+func TestRecordingReaderWithLimit(t *testing.T) {
+	text := "The quick brown fox"
+	r := ajio.NewRecordingReaderWithLimit(strings.NewReader(text), 5)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, text, string(data))
+	assert.Equal(t, "The q", string(r.Bytes()))
+	assert.True(t, r.Truncated())
+
+	replayed, err := io.ReadAll(r.Replay())
+	require.NoError(t, err)
+	assert.Equal(t, "The q", string(replayed))
+}
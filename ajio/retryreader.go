@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReopenFunc is called by a RetryReader to reopen the source so that reading can resume at offset.
+type ReopenFunc func(offset int64) (io.Reader, error)
+
+// RetryReader will reopen the source and resume reading from the same offset when a Read fails with a transient error.
+type RetryReader struct {
+	rd          io.Reader
+	reopen      ReopenFunc
+	offset      int64
+	retries     int
+	maxRetries  int
+	isTransient func(err error) bool
+}
+
+// Create a new RetryReader that reads from rd and calls reopen up to maxRetries times
+// (in total over the lifetime of the RetryReader) to resume reading after a transient error.
+// By default every error is considered to be transient except for io.EOF and context cancellation.
+func NewRetryReader(rd io.Reader, reopen ReopenFunc, maxRetries int) *RetryReader {
+	return &RetryReader{
+		rd:          rd,
+		reopen:      reopen,
+		maxRetries:  maxRetries,
+		isTransient: isTransientError,
+	}
+}
+
+// Set the function used to determine if a Read error is transient and thus should be retried.
+func (r *RetryReader) SetTransientFn(fn func(err error) bool) {
+	r.isTransient = fn
+}
+
+// io.Reader.
+func (r *RetryReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.rd.Read(p)
+		r.offset += int64(n)
+
+		if err == nil || !r.isTransient(err) {
+			return n, err
+		}
+
+		// Return the data already read and retry on the next call
+		if n > 0 {
+			if rerr := r.retry(err); rerr != nil {
+				return n, rerr
+			}
+			return n, nil
+		}
+
+		if rerr := r.retry(err); rerr != nil {
+			return 0, rerr
+		}
+	}
+}
+
+// Close the current source if it implements io.Closer.
+func (r *RetryReader) Close() error {
+	if c, ok := r.rd.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Return the number of bytes read so far.
+func (r *RetryReader) Offset() int64 {
+	return r.offset
+}
+
+// Return the number of times the source has been reopened.
+func (r *RetryReader) Retries() int {
+	return r.retries
+}
+
+func (r *RetryReader) retry(cause error) error {
+	if r.retries >= r.maxRetries {
+		return fmt.Errorf("failed to read after %d retries. %w", r.retries, cause)
+	}
+	r.retries++
+
+	if c, ok := r.rd.(io.Closer); ok {
+		_ = c.Close()
+	}
+
+	rd, err := r.reopen(r.offset)
+	if err != nil {
+		return fmt.Errorf("failed to reopen the source at offset %d. %w", r.offset, errors.Join(err, cause))
+	}
+	r.rd = rd
+	return nil
+}
+
+func isTransientError(err error) bool {
+	return !errors.Is(err, io.EOF) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errFlaky = errors.New("flaky")
+
+// Fails with errFlaky after reading failAfter bytes.
+type flakyReader struct {
+	rd        io.Reader
+	failAfter int
+	closed    bool
+}
+
+func (f *flakyReader) Read(p []byte) (int, error) {
+	if f.failAfter <= 0 {
+		return 0, errFlaky
+	}
+	if len(p) > f.failAfter {
+		p = p[:f.failAfter]
+	}
+	n, err := f.rd.Read(p)
+	f.failAfter -= n
+	return n, err
+}
+
+func (f *flakyReader) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestRetryReader(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+
+	var offsets []int64
+	var opened []*flakyReader
+	reopen := func(offset int64) (io.Reader, error) {
+		offsets = append(offsets, offset)
+		f := &flakyReader{rd: strings.NewReader(text[offset:]), failAfter: 10}
+		opened = append(opened, f)
+		return f, nil
+	}
+
+	first := &flakyReader{rd: strings.NewReader(text), failAfter: 10}
+	r := ajio.NewRetryReader(first, reopen, 10)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, text, string(data))
+	assert.Equal(t, []int64{10, 20, 30, 40}, offsets)
+	assert.Equal(t, 4, r.Retries())
+	assert.Equal(t, int64(len(text)), r.Offset())
+	assert.True(t, first.closed)
+
+	require.NoError(t, r.Close())
+	assert.True(t, opened[len(opened)-1].closed)
+}
+
+func TestRetryReaderBudgetExceeded(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+	reopen := func(offset int64) (io.Reader, error) {
+		return &flakyReader{rd: strings.NewReader(text[offset:]), failAfter: 5}, nil
+	}
+
+	r := ajio.NewRetryReader(&flakyReader{rd: strings.NewReader(text), failAfter: 5}, reopen, 2)
+	data, err := io.ReadAll(r)
+	assert.ErrorIs(t, err, errFlaky)
+	assert.Equal(t, text[:15], string(data))
+	assert.Equal(t, 2, r.Retries())
+}
+
+func TestRetryReaderReopenFails(t *testing.T) {
+	errReopen := errors.New("reopen failed")
+	reopen := func(offset int64) (io.Reader, error) {
+		return nil, errReopen
+	}
+
+	r := ajio.NewRetryReader(&flakyReader{rd: strings.NewReader("abc"), failAfter: 0}, reopen, 2)
+	_, err := r.Read(make([]byte, 4))
+	assert.ErrorIs(t, err, errReopen)
+	assert.ErrorIs(t, err, errFlaky)
+}
+
+func TestRetryReaderNotTransient(t *testing.T) {
+	reopen := func(offset int64) (io.Reader, error) {
+		require.Fail(t, "should not reopen")
+		return nil, nil
+	}
+
+	r := ajio.NewRetryReader(&flakyReader{rd: strings.NewReader("abc"), failAfter: 0}, reopen, 2)
+	r.SetTransientFn(func(err error) bool {
+		return !errors.Is(err, errFlaky)
+	})
+	_, err := r.Read(make([]byte, 4))
+	assert.ErrorIs(t, err, errFlaky)
+	assert.Equal(t, 0, r.Retries())
+}
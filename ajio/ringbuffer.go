@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+var (
+	// A non-blocking RingBuffer did not have enough space left to write all of the data.
+	ErrRingBufferFull = errors.New("ajio: ring buffer is full")
+
+	// A non-blocking RingBuffer did not have any data available to be read.
+	ErrRingBufferEmpty = errors.New("ajio: ring buffer is empty")
+)
+
+// RingBuffer is a fixed capacity in-memory buffer that implements io.Reader and io.Writer.
+// It can be used as a bounded pipe between a producer and a consumer goroutine.
+//
+// A blocking RingBuffer will block a Write until there is enough space and a Read until there is data available.
+// A non-blocking RingBuffer will write as much data as possible and then return [ErrRingBufferFull] and a Read
+// will return [ErrRingBufferEmpty] when there is no data available.
+//
+// Once Close has been called any Write will return [io.ErrClosedPipe] and a Read will return [io.EOF]
+// after all of the remaining data has been read.
+type RingBuffer struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	buf      []byte
+	start    int // index of the first unread byte
+	size     int // number of unread bytes
+	blocking bool
+	closed   bool
+}
+
+// Create a new RingBuffer that can hold at most capacity bytes.
+func NewRingBuffer(capacity int, blocking bool) *RingBuffer {
+	if capacity < 1 {
+		panic("ajio: the ring buffer capacity must be greater than 0")
+	}
+
+	r := &RingBuffer{
+		buf:      make([]byte, capacity),
+		blocking: blocking,
+	}
+	r.cond = sync.NewCond(&r.mu)
+	return r
+}
+
+// io.Reader.
+func (r *RingBuffer) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for r.size == 0 {
+		if r.closed {
+			return 0, io.EOF
+		}
+		if !r.blocking {
+			return 0, ErrRingBufferEmpty
+		}
+		r.cond.Wait()
+	}
+
+	n := r.read(p)
+	r.cond.Broadcast()
+	return n, nil
+}
+
+// io.Writer.
+func (r *RingBuffer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	written := 0
+	for written < len(p) {
+		if r.closed {
+			return written, io.ErrClosedPipe
+		}
+
+		if r.size == len(r.buf) {
+			if !r.blocking {
+				return written, ErrRingBufferFull
+			}
+			r.cond.Wait()
+			continue
+		}
+
+		written += r.write(p[written:])
+		r.cond.Broadcast()
+	}
+
+	return written, nil
+}
+
+// Close the RingBuffer for writing.
+// Any blocked Write will return [io.ErrClosedPipe] and readers will receive [io.EOF] once all the data has been read.
+func (r *RingBuffer) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	r.cond.Broadcast()
+	return nil
+}
+
+// Return the number of bytes that are available to be read.
+func (r *RingBuffer) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.size
+}
+
+// Return the maximum number of bytes the RingBuffer can hold.
+func (r *RingBuffer) Cap() int {
+	return len(r.buf)
+}
+
+// Discard all unread data and reopen the RingBuffer if it was closed.
+func (r *RingBuffer) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.start = 0
+	r.size = 0
+	r.closed = false
+	r.cond.Broadcast()
+}
+
+// Copy unread bytes into p. The caller must hold the lock.
+func (r *RingBuffer) read(p []byte) int {
+	n := 0
+	for n < len(p) && r.size > 0 {
+		end := min(r.start+r.size, len(r.buf))
+		c := copy(p[n:], r.buf[r.start:end])
+		n += c
+		r.size -= c
+		r.start = (r.start + c) % len(r.buf)
+	}
+
+	if r.size == 0 {
+		r.start = 0
+	}
+	return n
+}
+
+// Copy as much of p into the free space as possible. The caller must hold the lock.
+func (r *RingBuffer) write(p []byte) int {
+	n := 0
+	for n < len(p) && r.size < len(r.buf) {
+		pos := (r.start + r.size) % len(r.buf)
+		end := len(r.buf)
+		if pos < r.start {
+			end = r.start
+		}
+		c := copy(r.buf[pos:end], p[n:])
+		n += c
+		r.size += c
+	}
+	return n
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/andrejacobs/go-aj/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBufferNonBlocking(t *testing.T) {
+	r := ajio.NewRingBuffer(8, false)
+	assert.Equal(t, 8, r.Cap())
+	assert.Equal(t, 0, r.Len())
+
+	buffer := make([]byte, 4)
+	_, err := r.Read(buffer)
+	assert.ErrorIs(t, err, ajio.ErrRingBufferEmpty)
+
+	n, err := r.Write([]byte("The quick"))
+	assert.ErrorIs(t, err, ajio.ErrRingBufferFull)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, 8, r.Len())
+
+	n, err = r.Read(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "The ", string(buffer))
+
+	// Wrap around the end of the buffer
+	n, err = r.Write([]byte("k br"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	big := make([]byte, 10)
+	n, err = r.Read(big)
+	require.NoError(t, err)
+	assert.Equal(t, "quick br", string(big[:n]))
+
+	_, err = r.Write([]byte("own"))
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	_, err = r.Write([]byte("fox"))
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "own", string(data))
+
+	r.Reset()
+	_, err = r.Write([]byte("fox"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, r.Len())
+}
+
+func TestRingBufferBlocking(t *testing.T) {
+	expected := make([]byte, 100*1024)
+	require.NoError(t, random.SecureBytes(expected))
+
+	r := ajio.NewRingBuffer(1000, true)
+
+	go func() {
+		rd := bytes.NewReader(expected)
+		chunk := make([]byte, 777)
+		for {
+			n, err := rd.Read(chunk)
+			if err == io.EOF {
+				break
+			}
+			_, werr := r.Write(chunk[:n])
+			assert.NoError(t, werr)
+		}
+		r.Close()
+	}()
+
+	received, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, expected, received)
+}
+
+func TestRingBufferCloseUnblocksWriter(t *testing.T) {
+	r := ajio.NewRingBuffer(4, true)
+
+	done := make(chan error)
+	go func() {
+		_, err := r.Write([]byte("The quick brown fox"))
+		done <- err
+	}()
+
+	require.Eventually(t, func() bool { return r.Len() == 4 }, time.Second, time.Millisecond)
+	require.NoError(t, r.Close())
+	assert.ErrorIs(t, <-done, io.ErrClosedPipe)
+}
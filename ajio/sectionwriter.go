@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"errors"
+	"io"
+	"math"
+)
+
+var (
+	// A write would have gone beyond the end of the section.
+	ErrSectionOverflow = errors.New("ajio: write beyond the end of the section")
+
+	errWhence = errors.New("ajio: invalid whence")
+	errOffset = errors.New("ajio: invalid offset")
+)
+
+// SectionWriter implements Write, WriteAt and Seek on a section of an underlying [io.WriterAt].
+// It is the writing counterpart of [io.SectionReader].
+type SectionWriter struct {
+	w     io.WriterAt
+	base  int64 // the offset at which the section starts
+	off   int64 // the current offset
+	limit int64 // the offset at which the section ends
+}
+
+// Create a new SectionWriter that writes to w starting at offset off and stops with [ErrSectionOverflow] after n bytes.
+// NOTE: Taken from io.NewSectionReader in io/io.go and modified to create a SectionWriter.
+func NewSectionWriter(w io.WriterAt, off int64, n int64) *SectionWriter {
+	var remaining int64
+	const maxint64 = math.MaxInt64
+	if off <= maxint64-n {
+		remaining = n + off
+	} else {
+		// Overflow, with no way to return an error.
+		// Assume we can write up to an offset of 1<<63 - 1.
+		remaining = maxint64
+	}
+	return &SectionWriter{w: w, base: off, off: off, limit: remaining}
+}
+
+// io.Writer.
+// If p does not fit in the remainder of the section then as much as possible is written
+// and [ErrSectionOverflow] is returned.
+func (s *SectionWriter) Write(p []byte) (int, error) {
+	if s.off >= s.limit {
+		return 0, ErrSectionOverflow
+	}
+
+	var overflow bool
+	if max := s.limit - s.off; int64(len(p)) > max {
+		p = p[0:max]
+		overflow = true
+	}
+
+	n, err := s.w.WriteAt(p, s.off)
+	s.off += int64(n)
+	if err == nil && overflow {
+		err = ErrSectionOverflow
+	}
+	return n, err
+}
+
+// io.WriterAt.
+// off is relative to the start of the section.
+// If p does not fit in the remainder of the section then as much as possible is written
+// and [ErrSectionOverflow] is returned.
+func (s *SectionWriter) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 || off >= s.Size() {
+		return 0, ErrSectionOverflow
+	}
+
+	off += s.base
+	var overflow bool
+	if max := s.limit - off; int64(len(p)) > max {
+		p = p[0:max]
+		overflow = true
+	}
+
+	n, err := s.w.WriteAt(p, off)
+	if err == nil && overflow {
+		err = ErrSectionOverflow
+	}
+	return n, err
+}
+
+// io.Seeker.
+// The offset is relative to the start of the section.
+// NOTE: Taken from (*io.SectionReader).Seek in io/io.go and modified to seek within a SectionWriter.
+func (s *SectionWriter) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	default:
+		return 0, errWhence
+	case io.SeekStart:
+		offset += s.base
+	case io.SeekCurrent:
+		offset += s.off
+	case io.SeekEnd:
+		offset += s.limit
+	}
+	if offset < s.base {
+		return 0, errOffset
+	}
+	s.off = offset
+	return offset - s.base, nil
+}
+
+// Return the size of the section in bytes.
+func (s *SectionWriter) Size() int64 {
+	return s.limit - s.base
+}
+
+// Return the underlying [io.WriterAt] and the offset and size of the section.
+func (s *SectionWriter) Outer() (w io.WriterAt, off int64, n int64) {
+	return s.w, s.base, s.limit - s.base
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSectionWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unit-testing")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.WriteString("................")
+	require.NoError(t, err)
+
+	s := ajio.NewSectionWriter(f, 4, 8)
+	assert.Equal(t, int64(8), s.Size())
+	outer, off, n := s.Outer()
+	assert.Equal(t, f, outer)
+	assert.Equal(t, int64(4), off)
+	assert.Equal(t, int64(8), n)
+
+	count, err := s.Write([]byte("quick"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	count, err = s.Write([]byte("brown"))
+	assert.ErrorIs(t, err, ajio.ErrSectionOverflow)
+	assert.Equal(t, 3, count)
+
+	_, err = s.Write([]byte("x"))
+	assert.ErrorIs(t, err, ajio.ErrSectionOverflow)
+
+	count, err = s.WriteAt([]byte("QU"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = s.WriteAt([]byte("abc"), 6)
+	assert.ErrorIs(t, err, ajio.ErrSectionOverflow)
+	assert.Equal(t, 2, count)
+
+	_, err = s.WriteAt([]byte("a"), 8)
+	assert.ErrorIs(t, err, ajio.ErrSectionOverflow)
+	_, err = s.WriteAt([]byte("a"), -1)
+	assert.ErrorIs(t, err, ajio.ErrSectionOverflow)
+
+	pos, err := s.Seek(-3, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), pos)
+	_, err = s.Write([]byte("B"))
+	require.NoError(t, err)
+
+	pos, err = s.Seek(-1, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), pos)
+
+	_, err = s.Seek(-1, io.SeekStart)
+	assert.Error(t, err)
+	_, err = s.Seek(0, 42)
+	assert.Error(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "....QUickBab....", string(data))
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"io"
+	"os"
+)
+
+// DefaultSparseRun is the default minimum number of consecutive zero bytes that will be skipped by a SparseWriter.
+const DefaultSparseRun = 4096
+
+// SparseWriter writes to an os.File and skips over long runs of zero bytes by seeking forward instead of
+// writing them. On file systems that support it this produces a sparse file.
+// Flush must be called once all the data has been written to ensure the file has the correct size
+// when it ends with skipped zeros.
+type SparseWriter struct {
+	f        *os.File
+	minRun   int
+	offset   int64 // the logical offset
+	needSeek bool  // the file's offset is behind the logical offset
+}
+
+// Create a new SparseWriter that will start writing at the current offset of f.
+// minRun is the minimum number of consecutive zero bytes (within a single Write) that will be skipped.
+// A minRun of 0 or less will use [DefaultSparseRun].
+func NewSparseWriter(f *os.File, minRun int) (*SparseWriter, error) {
+	if minRun <= 0 {
+		minRun = DefaultSparseRun
+	}
+
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SparseWriter{
+		f:      f,
+		minRun: minRun,
+		offset: offset,
+	}, nil
+}
+
+// io.Writer.
+func (w *SparseWriter) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		data, zeros := w.nextSegment(p[written:])
+
+		if len(data) > 0 {
+			if err := w.seekIfNeeded(); err != nil {
+				return written, err
+			}
+			n, err := w.f.Write(data)
+			written += n
+			w.offset += int64(n)
+			if err != nil {
+				return written, err
+			}
+		}
+
+		if zeros > 0 {
+			written += zeros
+			w.offset += int64(zeros)
+			w.needSeek = true
+		}
+	}
+
+	return written, nil
+}
+
+// Ensure the file is extended to include any trailing zero bytes that were skipped.
+func (w *SparseWriter) Flush() error {
+	if !w.needSeek {
+		return nil
+	}
+
+	info, err := w.f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() < w.offset {
+		if err := w.f.Truncate(w.offset); err != nil {
+			return err
+		}
+	}
+
+	return w.seekIfNeeded()
+}
+
+// Return the logical offset in bytes.
+func (w *SparseWriter) Offset() int64 {
+	return w.offset
+}
+
+func (w *SparseWriter) seekIfNeeded() error {
+	if !w.needSeek {
+		return nil
+	}
+	if _, err := w.f.Seek(w.offset, io.SeekStart); err != nil {
+		return err
+	}
+	w.needSeek = false
+	return nil
+}
+
+// Split p into the data that needs to be written followed by the number of zero bytes that can be skipped.
+func (w *SparseWriter) nextSegment(p []byte) ([]byte, int) {
+	runStart := -1
+	for i, b := range p {
+		if b != 0 {
+			runStart = -1
+			continue
+		}
+
+		if runStart < 0 {
+			runStart = i
+		}
+		if i-runStart+1 >= w.minRun {
+			// Found a long enough run, extend it as far as possible
+			end := i + 1
+			for end < len(p) && p[end] == 0 {
+				end++
+			}
+			return p[:runStart], end - runStart
+		}
+	}
+
+	return p, 0
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unit-testing")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w, err := ajio.NewSparseWriter(f, 8)
+	require.NoError(t, err)
+
+	var expected bytes.Buffer
+	chunks := [][]byte{
+		[]byte("The quick"),
+		make([]byte, 100),
+		append([]byte("brown\x00\x00\x00fox"), make([]byte, 20)...),
+		[]byte("jumped"),
+		make([]byte, 50),
+	}
+	for _, chunk := range chunks {
+		n, err := w.Write(chunk)
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+		expected.Write(chunk)
+	}
+	assert.Equal(t, int64(expected.Len()), w.Offset())
+
+	require.NoError(t, w.Flush())
+	offset, err := f.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.Equal(t, int64(expected.Len()), offset)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), data)
+}
+
+func TestSparseWriterDefaultRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unit-testing")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w, err := ajio.NewSparseWriter(f, 0)
+	require.NoError(t, err)
+
+	expected := make([]byte, ajio.DefaultSparseRun*3)
+	expected[0] = 'A'
+	expected[len(expected)-1] = 'J'
+	_, err = w.Write(expected)
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// IOStats is a snapshot of the statistics collected by a StatsReader or StatsWriter.
+type IOStats struct {
+	Bytes    uint64        // Total number of bytes transferred
+	Calls    uint64        // Number of calls made to Read or Write
+	MinChunk int           // Smallest number of bytes transferred by a single call
+	MaxChunk int           // Largest number of bytes transferred by a single call
+	Elapsed  time.Duration // Time from the start of the first call until the end of the last call
+	Busy     time.Duration // Time spent inside the underlying Read or Write calls
+}
+
+// Return the average number of bytes transferred per call.
+func (s IOStats) AvgChunk() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Bytes) / float64(s.Calls)
+}
+
+// StatsReader collects statistics about the Read calls made to an io.Reader.
+type StatsReader struct {
+	rd io.Reader
+	ioStatsCollector
+}
+
+// Create a new StatsReader that will collect statistics about reading from rd.
+func NewStatsReader(rd io.Reader) *StatsReader {
+	return &StatsReader{rd: rd}
+}
+
+// io.Reader.
+func (r *StatsReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	n, err := r.rd.Read(p)
+	r.record(n, start, time.Now())
+	return n, err
+}
+
+// StatsWriter collects statistics about the Write calls made to an io.Writer.
+type StatsWriter struct {
+	wd io.Writer
+	ioStatsCollector
+}
+
+// Create a new StatsWriter that will collect statistics about writing to wd.
+func NewStatsWriter(wd io.Writer) *StatsWriter {
+	return &StatsWriter{wd: wd}
+}
+
+// io.Writer.
+func (w *StatsWriter) Write(p []byte) (int, error) {
+	start := time.Now()
+	n, err := w.wd.Write(p)
+	w.record(n, start, time.Now())
+	return n, err
+}
+
+//-----------------------------------------------------------------------------
+
+type ioStatsCollector struct {
+	mu    sync.Mutex
+	stats IOStats
+	first time.Time
+}
+
+// Return a copy of the statistics collected so far.
+// Safe to be called from a different goroutine than the one doing the reading or writing.
+func (c *ioStatsCollector) Snapshot() IOStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Discard all of the statistics collected so far.
+func (c *ioStatsCollector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = IOStats{}
+	c.first = time.Time{}
+}
+
+func (c *ioStatsCollector) record(n int, start time.Time, end time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stats.Calls == 0 {
+		c.first = start
+		c.stats.MinChunk = n
+		c.stats.MaxChunk = n
+	} else {
+		c.stats.MinChunk = min(c.stats.MinChunk, n)
+		c.stats.MaxChunk = max(c.stats.MaxChunk, n)
+	}
+
+	c.stats.Calls++
+	if n > 0 {
+		c.stats.Bytes += uint64(n)
+	}
+	c.stats.Busy += end.Sub(start)
+	c.stats.Elapsed = end.Sub(c.first)
+}
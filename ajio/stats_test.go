@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsReader(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+	r := ajio.NewStatsReader(iotest.HalfReader(strings.NewReader(text)))
+
+	stats := r.Snapshot()
+	assert.Equal(t, uint64(0), stats.Calls)
+	assert.Equal(t, float64(0), stats.AvgChunk())
+
+	buffer := make([]byte, 10)
+	_, err := r.Read(buffer)
+	require.NoError(t, err)
+	_, err = r.Read(buffer[:2])
+	require.NoError(t, err)
+
+	stats = r.Snapshot()
+	assert.Equal(t, uint64(2), stats.Calls)
+	assert.Equal(t, uint64(6), stats.Bytes)
+	assert.Equal(t, 1, stats.MinChunk)
+	assert.Equal(t, 5, stats.MaxChunk)
+	assert.InDelta(t, 3.0, stats.AvgChunk(), 0.001)
+	assert.GreaterOrEqual(t, stats.Elapsed, stats.Busy)
+
+	r.Reset()
+	assert.Equal(t, ajio.IOStats{}, r.Snapshot())
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, text[6:], string(data))
+	assert.Equal(t, uint64(len(text)-6), r.Snapshot().Bytes)
+	assert.Equal(t, 0, r.Snapshot().MinChunk) // The final read returns io.EOF
+}
+
+func TestStatsWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := ajio.NewStatsWriter(&buf)
+
+	for _, s := range []string{"The", " quick brown", " fox"} {
+		_, err := io.WriteString(w, s)
+		require.NoError(t, err)
+	}
+
+	stats := w.Snapshot()
+	assert.Equal(t, "The quick brown fox", buf.String())
+	assert.Equal(t, uint64(3), stats.Calls)
+	assert.Equal(t, uint64(19), stats.Bytes)
+	assert.Equal(t, 3, stats.MinChunk)
+	assert.Equal(t, 12, stats.MaxChunk)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// All of the writers of a TolerantMultiWriter have failed.
+var ErrAllWritersFailed = errors.New("ajio: all writers have failed")
+
+// TolerantMultiWriter duplicates writes to multiple writers, similar to [io.MultiWriter], however a
+// failure of one writer does not stop the data being written to the others.
+// The first error of each writer is recorded and that writer is skipped from then on.
+// The recorded errors are returned by Close.
+type TolerantMultiWriter struct {
+	writers []io.Writer
+	errs    []error
+	failed  int
+}
+
+// Create a new TolerantMultiWriter that duplicates its writes to all the provided writers.
+func NewTolerantMultiWriter(writers ...io.Writer) *TolerantMultiWriter {
+	return &TolerantMultiWriter{
+		writers: writers,
+		errs:    make([]error, len(writers)),
+	}
+}
+
+// io.Writer.
+// Returns [ErrAllWritersFailed] (joined with the recorded errors) only when every writer has failed.
+func (t *TolerantMultiWriter) Write(p []byte) (int, error) {
+	for i, w := range t.writers {
+		if t.errs[i] != nil {
+			continue
+		}
+
+		n, err := w.Write(p)
+		if err == nil && n != len(p) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			t.errs[i] = fmt.Errorf("writer [%d] failed. %w", i, err)
+			t.failed++
+		}
+	}
+
+	if t.failed == len(t.writers) && len(t.writers) > 0 {
+		return 0, errors.Join(append([]error{ErrAllWritersFailed}, t.errs...)...)
+	}
+	return len(p), nil
+}
+
+// Return the error recorded for each writer in the same order as the writers were provided.
+// A nil entry means the writer has not failed.
+func (t *TolerantMultiWriter) Errors() []error {
+	return append([]error(nil), t.errs...)
+}
+
+// Close every writer that implements io.Closer and return all the errors that were recorded
+// while writing and closing.
+func (t *TolerantMultiWriter) Close() error {
+	errs := make([]error, 0, len(t.writers))
+	for i, w := range t.writers {
+		errs = append(errs, t.errs[i])
+		if c, ok := w.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("writer [%d] failed to close. %w", i, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
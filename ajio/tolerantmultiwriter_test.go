@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBrokenWriter = errors.New("broken writer")
+
+type brokenWriter struct {
+	closed bool
+}
+
+func (w *brokenWriter) Write(p []byte) (int, error) {
+	return 0, errBrokenWriter
+}
+
+func (w *brokenWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestTolerantMultiWriter(t *testing.T) {
+	var a, b bytes.Buffer
+	broken := &brokenWriter{}
+	w := ajio.NewTolerantMultiWriter(&a, broken, &b)
+
+	n, err := w.Write([]byte("The quick"))
+	require.NoError(t, err)
+	assert.Equal(t, 9, n)
+	n, err = w.Write([]byte(" brown fox"))
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+
+	assert.Equal(t, "The quick brown fox", a.String())
+	assert.Equal(t, "The quick brown fox", b.String())
+
+	errs := w.Errors()
+	require.Len(t, errs, 3)
+	assert.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[1], errBrokenWriter)
+	assert.NoError(t, errs[2])
+
+	err = w.Close()
+	assert.ErrorIs(t, err, errBrokenWriter)
+	assert.True(t, broken.closed)
+}
+
+func TestTolerantMultiWriterAllFailed(t *testing.T) {
+	w := ajio.NewTolerantMultiWriter(&brokenWriter{}, &brokenWriter{})
+	_, err := w.Write([]byte("The quick"))
+	assert.ErrorIs(t, err, ajio.ErrAllWritersFailed)
+	assert.ErrorIs(t, err, errBrokenWriter)
+}
+
+func TestTolerantMultiWriterNoErrors(t *testing.T) {
+	var a bytes.Buffer
+	w := ajio.NewTolerantMultiWriter(&a)
+	_, err := w.Write([]byte("The quick"))
+	require.NoError(t, err)
+	assert.NoError(t, w.Close())
+}
@@ -31,12 +31,18 @@ import (
 
 // File wraps an os.File and keeps track of the current offset without requiring constant calls to Seek which involves syscall Lseek to be made.
 // Reading and Writing is buffered by using the bufio package.
-// Implements the following interfaces: io.Reader, io.Writer, io.Seeker, io.ByteReader.
+// Implements the following interfaces: io.Reader, io.Writer, io.Seeker, io.ByteScanner, io.ByteWriter,
+// io.RuneReader. Peek and Discard are also provided by delegating to the internal bufio.Reader.
 type File struct {
 	of     *os.File
 	reader *bufio.Reader
 	writer *bufio.Writer
 	offset uint64
+
+	marked     bool
+	markOffset uint64
+
+	progress *progressReporter
 }
 
 // Create a new File.
@@ -85,6 +91,7 @@ func (f *File) Read(p []byte) (int, error) {
 		return 0, err
 	}
 	f.offset = newOffset
+	f.progress.update(f.offset)
 
 	return n, nil
 }
@@ -101,6 +108,7 @@ func (f *File) ReadByte() (byte, error) {
 		return 0, err
 	}
 	f.offset = newOffset
+	f.progress.update(f.offset)
 	return b, nil
 }
 
@@ -137,6 +145,7 @@ func (f *File) Discard(n int) (int, error) {
 		return 0, err
 	}
 	f.offset = newOffset
+	f.progress.update(f.offset)
 
 	return rn, nil
 }
@@ -153,6 +162,7 @@ func (f *File) Write(p []byte) (int, error) {
 		return 0, err
 	}
 	f.offset = newOffset
+	f.progress.update(f.offset)
 
 	return n, nil
 }
@@ -168,6 +178,7 @@ func (f *File) WriteByte(c byte) error {
 		return err
 	}
 	f.offset = newOffset
+	f.progress.update(f.offset)
 	return nil
 }
 
@@ -185,6 +196,7 @@ func (f *File) ReadRune() (rune, int, error) {
 		return r, s, err
 	}
 	f.offset = newOffset
+	f.progress.update(f.offset)
 
 	return r, s, nil
 }
@@ -202,6 +214,7 @@ func (f *File) WriteRune(r rune) (int, error) {
 		return 0, err
 	}
 	f.offset = newOffset
+	f.progress.update(f.offset)
 
 	return n, nil
 }
@@ -232,6 +245,45 @@ func (f *File) SetOffset(newOffset uint64) {
 	f.offset = newOffset
 }
 
+// Call fn every time the offset crosses a multiple of interval bytes or reaches total.
+// total is the expected size in bytes and can be 0 when it is not known.
+// Pass a nil fn to stop reporting progress.
+func (f *File) SetProgressFn(interval uint64, total uint64, fn ProgressFn) {
+	f.progress = newProgressReporter(interval, total, f.offset, fn)
+}
+
+// Mark the current offset so that it can be returned to by calling ResetToMark.
+// Calling Mark again will replace the previous mark.
+func (f *File) Mark() {
+	f.marked = true
+	f.markOffset = f.offset
+}
+
+// Seek back to the offset that was recorded by the last call to Mark and discard any buffered read data.
+// The mark remains set and thus ResetToMark can be called multiple times.
+// Returns [ErrNoMark] if Mark has not been called.
+func (f *File) ResetToMark() error {
+	if !f.marked {
+		return ErrNoMark
+	}
+
+	offset, err := safe.Uint64ToInt64(f.markOffset)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	f.ResetReadBuffer()
+	return nil
+}
+
+// Remove the mark.
+func (f *File) ClearMark() {
+	f.marked = false
+}
+
 // Ensure the File's offset and the underlying os.File's actual offsets are the same.
 // This will make a call to file.Seek.
 func (f *File) SyncOffset() error {
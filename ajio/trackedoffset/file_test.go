@@ -349,3 +349,81 @@ func TestWriteReadRune(t *testing.T) {
 	assert.Equal(t, '語', r)
 	assert.Equal(t, uint64(6), f.Offset())
 }
+
+func TestFileMark(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "unit-testing")
+
+	f, err := os.Create(tempFile)
+	require.NoError(t, err)
+	_, err = f.WriteString("The quick brown fox jumped over the lazy dog!")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	tracker, err := trackedoffset.Open(tempFile)
+	require.NoError(t, err)
+	defer tracker.Close()
+
+	assert.ErrorIs(t, tracker.ResetToMark(), trackedoffset.ErrNoMark)
+
+	_, err = tracker.Discard(4)
+	require.NoError(t, err)
+	tracker.Mark()
+
+	var buffer [5]byte
+	_, err = io.ReadFull(tracker, buffer[:])
+	require.NoError(t, err)
+	assert.Equal(t, "quick", string(buffer[:]))
+	assert.Equal(t, uint64(9), tracker.Offset())
+
+	require.NoError(t, tracker.ResetToMark())
+	assert.Equal(t, uint64(4), tracker.Offset())
+
+	_, err = io.ReadFull(tracker, buffer[:])
+	require.NoError(t, err)
+	assert.Equal(t, "quick", string(buffer[:]))
+
+	tracker.ClearMark()
+	assert.ErrorIs(t, tracker.ResetToMark(), trackedoffset.ErrNoMark)
+}
+
+func TestFileInterfaces(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "unit-testing")
+	tracker, err := trackedoffset.Create(tempFile)
+	require.NoError(t, err)
+	defer tracker.Close()
+
+	assert.Implements(t, (*io.ReadWriteSeeker)(nil), tracker)
+	assert.Implements(t, (*io.ByteScanner)(nil), tracker)
+	assert.Implements(t, (*io.ByteWriter)(nil), tracker)
+	assert.Implements(t, (*io.RuneReader)(nil), tracker)
+
+	require.NoError(t, tracker.WriteByte('A'))
+	require.NoError(t, tracker.WriteByte('J'))
+	_, err = tracker.Write([]byte("42"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), tracker.Offset())
+	require.NoError(t, tracker.Flush())
+
+	_, err = tracker.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	tracker.ResetReadBuffer()
+
+	data, err := tracker.Peek(2)
+	require.NoError(t, err)
+	assert.Equal(t, "AJ", string(data))
+	assert.Equal(t, uint64(0), tracker.Offset())
+
+	b, err := tracker.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte('A'), b)
+	assert.Equal(t, uint64(1), tracker.Offset())
+
+	count, err := tracker.Discard(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, uint64(2), tracker.Offset())
+
+	data, err = tracker.Peek(2)
+	require.NoError(t, err)
+	assert.Equal(t, "42", string(data))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package trackedoffset
+
+import (
+	"hash"
+	"io"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+)
+
+// HashReader keeps track of the offset within an io.Reader source while also
+// writing every byte that was read to a hash.Hash.
+type HashReader struct {
+	rd     io.Reader
+	hasher hash.Hash
+	offset uint64
+}
+
+// Create a new HashReader that will keep track of the offset within the source io.Reader
+// and calculate the hash of the data read using hasher.
+// baseOffset is the known starting offset.
+func NewHashReader(rd io.Reader, hasher hash.Hash, baseOffset uint64) *HashReader {
+	r := &HashReader{
+		rd:     rd,
+		hasher: hasher,
+		offset: baseOffset,
+	}
+	return r
+}
+
+// Reader implementation.
+// Bytes returned together with an error (e.g. io.EOF) are also hashed and counted.
+func (r *HashReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	if n > 0 {
+		// hash.Hash.Write never returns an error
+		_, _ = r.hasher.Write(p[:n])
+
+		newOffset, oerr := safe.Add64(r.offset, uint64(n))
+		if oerr != nil {
+			return 0, oerr
+		}
+		r.offset = newOffset
+	}
+
+	return n, err
+}
+
+// Return the current offset in bytes.
+func (r *HashReader) Offset() uint64 {
+	return r.offset
+}
+
+// Set the known offset in bytes.
+func (r *HashReader) ResetOffset(offset uint64) {
+	r.offset = offset
+}
+
+// Return the hash of all the data read so far.
+func (r *HashReader) Sum() []byte {
+	return r.hasher.Sum(nil)
+}
+
+// Return the hash of all the data read so far and the current offset in bytes.
+func (r *HashReader) SumAndOffset() ([]byte, uint64) {
+	return r.Sum(), r.offset
+}
+
+// Access the underlying hash.Hash.
+func (r *HashReader) Hasher() hash.Hash {
+	return r.hasher
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package trackedoffset_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio/trackedoffset"
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashReader(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+	tr := trackedoffset.NewHashReader(strings.NewReader(text), sha256.New(), 42)
+	assert.Equal(t, uint64(42), tr.Offset())
+
+	var copied bytes.Buffer
+	count, err := io.Copy(&copied, tr)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(text)), count)
+	assert.Equal(t, text, copied.String())
+
+	expected := sha256.Sum256([]byte(text))
+	sum, offset := tr.SumAndOffset()
+	assert.Equal(t, expected[:], sum)
+	assert.Equal(t, uint64(42+len(text)), offset)
+	assert.Equal(t, expected[:], tr.Hasher().Sum(nil))
+
+	tr.ResetOffset(1)
+	assert.Equal(t, uint64(1), tr.Offset())
+}
+
+func TestHashReaderDataWithEOF(t *testing.T) {
+	text := "The quick brown fox"
+	tr := trackedoffset.NewHashReader(&dataWithEOFReader{data: []byte(text)}, sha256.New(), 0)
+
+	buffer := make([]byte, 100)
+	n, err := tr.Read(buffer)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, len(text), n)
+
+	expected := sha256.Sum256([]byte(text))
+	assert.Equal(t, expected[:], tr.Sum())
+	assert.Equal(t, uint64(len(text)), tr.Offset())
+}
+
+func TestHashReaderOverflow(t *testing.T) {
+	tr := trackedoffset.NewHashReader(strings.NewReader("overflow"), sha256.New(), math.MaxUint64-2)
+
+	buffer := make([]byte, 4)
+	_, err := tr.Read(buffer)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+// Returns all of the data and io.EOF in a single Read.
+type dataWithEOFReader struct {
+	data []byte
+}
+
+func (r *dataWithEOFReader) Read(p []byte) (int, error) {
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	if len(r.data) == 0 {
+		return n, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package trackedoffset
+
+import (
+	"io"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+)
+
+// LineReader keeps track of the line, column and offset within an io.Reader source.
+// Lines are separated by '\n' and columns are counted in UTF-8 encoded runes.
+// Both the line and column numbers start at 1.
+type LineReader struct {
+	rd     io.Reader
+	offset uint64
+	line   uint64
+	col    uint64
+}
+
+// Create a new LineReader that will keep track of the line, column and offset within the source io.Reader.
+// baseOffset is the known starting offset.
+func NewLineReader(rd io.Reader, baseOffset uint64) *LineReader {
+	r := &LineReader{
+		rd:     rd,
+		offset: baseOffset,
+		line:   1,
+		col:    1,
+	}
+	return r
+}
+
+// Reader implementation.
+func (r *LineReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	if err != nil {
+		return n, err
+	}
+
+	newOffset, err := safe.Add64(r.offset, uint64(n))
+	if err != nil {
+		return 0, err
+	}
+	r.offset = newOffset
+
+	for _, b := range p[:n] {
+		if b == '\n' {
+			r.line++
+			r.col = 1
+		} else if !isUTF8Continuation(b) {
+			r.col++
+		}
+	}
+
+	return n, nil
+}
+
+// Return the line and column of the next rune to be read and the current offset in bytes.
+func (r *LineReader) Position() (line uint64, col uint64, offset uint64) {
+	return r.line, r.col, r.offset
+}
+
+// Return the current offset in bytes.
+func (r *LineReader) Offset() uint64 {
+	return r.offset
+}
+
+// Set the known line, column and offset.
+func (r *LineReader) ResetPosition(line uint64, col uint64, offset uint64) {
+	r.line = line
+	r.col = col
+	r.offset = offset
+}
+
+// Bytes with the bit pattern 10xxxxxx are the trailing bytes of a multi-byte UTF-8 encoded rune.
+func isUTF8Continuation(b byte) bool {
+	return b&0xC0 == 0x80
+}
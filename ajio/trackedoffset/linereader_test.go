@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package trackedoffset_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/andrejacobs/go-aj/ajio/trackedoffset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineReader(t *testing.T) {
+	text := "The quick\nbrown fox\n\njumped"
+	tr := trackedoffset.NewLineReader(strings.NewReader(text), 0)
+
+	line, col, offset := tr.Position()
+	assert.Equal(t, uint64(1), line)
+	assert.Equal(t, uint64(1), col)
+	assert.Equal(t, uint64(0), offset)
+
+	buffer := make([]byte, 4)
+	_, err := io.ReadFull(tr, buffer)
+	require.NoError(t, err)
+	line, col, offset = tr.Position()
+	assert.Equal(t, uint64(1), line)
+	assert.Equal(t, uint64(5), col)
+	assert.Equal(t, uint64(4), offset)
+
+	buffer = make([]byte, 12)
+	_, err = io.ReadFull(tr, buffer)
+	require.NoError(t, err)
+	line, col, offset = tr.Position()
+	assert.Equal(t, uint64(2), line)
+	assert.Equal(t, uint64(7), col)
+	assert.Equal(t, uint64(16), offset)
+
+	_, err = io.ReadAll(tr)
+	require.NoError(t, err)
+	line, col, offset = tr.Position()
+	assert.Equal(t, uint64(4), line)
+	assert.Equal(t, uint64(7), col)
+	assert.Equal(t, uint64(len(text)), offset)
+	assert.Equal(t, offset, tr.Offset())
+
+	tr.ResetPosition(10, 2, 100)
+	line, col, offset = tr.Position()
+	assert.Equal(t, uint64(10), line)
+	assert.Equal(t, uint64(2), col)
+	assert.Equal(t, uint64(100), offset)
+}
+
+func TestLineReaderMultiByteRunes(t *testing.T) {
+	text := "ส語\naé"
+	// Read one byte at a time so that the runes are split across reads
+	tr := trackedoffset.NewLineReader(iotest.OneByteReader(strings.NewReader(text)), 0)
+
+	buffer := make([]byte, 6)
+	_, err := io.ReadFull(tr, buffer)
+	require.NoError(t, err)
+	line, col, _ := tr.Position()
+	assert.Equal(t, uint64(1), line)
+	assert.Equal(t, uint64(3), col)
+
+	_, err = io.ReadAll(tr)
+	require.NoError(t, err)
+	line, col, offset := tr.Position()
+	assert.Equal(t, uint64(2), line)
+	assert.Equal(t, uint64(3), col)
+	assert.Equal(t, uint64(len(text)), offset)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package trackedoffset
+
+// Progress describes how far along reading or writing is.
+type Progress struct {
+	Offset  uint64  // The current offset in bytes
+	Total   uint64  // The total size in bytes or 0 if it is not known
+	Percent float64 // Percentage (0 to 100) of Total that has been reached or -1 if Total is not known
+}
+
+// ProgressFn is called when progress is made.
+type ProgressFn func(p Progress)
+
+// Create a ProgressFn that will publish the progress on the channel.
+// The send will not block and thus updates are dropped if the channel is not ready to receive.
+func ProgressChannel(ch chan<- Progress) ProgressFn {
+	return func(p Progress) {
+		select {
+		case ch <- p:
+		default:
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+type progressReporter struct {
+	interval uint64
+	total    uint64
+	fn       ProgressFn
+	bucket   uint64
+	last     uint64
+}
+
+func newProgressReporter(interval uint64, total uint64, offset uint64, fn ProgressFn) *progressReporter {
+	if fn == nil {
+		return nil
+	}
+	if interval == 0 {
+		interval = 1
+	}
+
+	return &progressReporter{
+		interval: interval,
+		total:    total,
+		fn:       fn,
+		bucket:   offset / interval,
+		last:     offset,
+	}
+}
+
+// Call the progress function every time the offset crosses an interval boundary or reaches the total.
+func (p *progressReporter) update(offset uint64) {
+	if p == nil || offset == p.last {
+		return
+	}
+
+	bucket := offset / p.interval
+	reachedTotal := (p.total > 0) && (offset == p.total)
+	if (bucket == p.bucket) && !reachedTotal {
+		p.last = offset
+		return
+	}
+
+	p.bucket = bucket
+	p.last = offset
+
+	progress := Progress{
+		Offset:  offset,
+		Total:   p.total,
+		Percent: -1,
+	}
+	if p.total > 0 {
+		progress.Percent = float64(offset) / float64(p.total) * 100.0
+	}
+	p.fn(progress)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package trackedoffset_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio/trackedoffset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderProgress(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+	tr := trackedoffset.NewReader(strings.NewReader(text), 0)
+
+	var reported []trackedoffset.Progress
+	tr.SetProgressFn(10, uint64(len(text)), func(p trackedoffset.Progress) {
+		reported = append(reported, p)
+	})
+
+	buffer := make([]byte, 4)
+	for {
+		_, err := tr.Read(buffer)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	require.Len(t, reported, 5)
+	assert.Equal(t, uint64(12), reported[0].Offset)
+	assert.Equal(t, uint64(20), reported[1].Offset)
+	assert.Equal(t, uint64(32), reported[2].Offset)
+	assert.Equal(t, uint64(40), reported[3].Offset)
+	assert.Equal(t, uint64(len(text)), reported[4].Offset)
+	assert.Equal(t, uint64(len(text)), reported[4].Total)
+	assert.InDelta(t, 100.0, reported[4].Percent, 0.001)
+	assert.InDelta(t, float64(12)/float64(len(text))*100.0, reported[0].Percent, 0.001)
+}
+
+func TestWriterProgressUnknownTotal(t *testing.T) {
+	tw := trackedoffset.NewWriter(io.Discard, 0)
+
+	var reported []trackedoffset.Progress
+	tw.SetProgressFn(4, 0, func(p trackedoffset.Progress) {
+		reported = append(reported, p)
+	})
+
+	for i := 0; i < 5; i++ {
+		_, err := tw.Write([]byte("ab"))
+		require.NoError(t, err)
+	}
+
+	require.Len(t, reported, 2)
+	assert.Equal(t, uint64(4), reported[0].Offset)
+	assert.Equal(t, uint64(8), reported[1].Offset)
+	assert.Equal(t, float64(-1), reported[1].Percent)
+
+	// Stop reporting
+	tw.SetProgressFn(4, 0, nil)
+	_, err := tw.Write([]byte("abcd"))
+	require.NoError(t, err)
+	assert.Len(t, reported, 2)
+}
+
+func TestFileProgressChannel(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "unit-testing")
+	tracker, err := trackedoffset.Create(tempFile)
+	require.NoError(t, err)
+	defer os.Remove(tempFile)
+	defer tracker.Close()
+
+	ch := make(chan trackedoffset.Progress, 10)
+	tracker.SetProgressFn(2, 4, trackedoffset.ProgressChannel(ch))
+
+	require.NoError(t, tracker.WriteByte('a'))
+	require.NoError(t, tracker.WriteByte('b'))
+	_, err = tracker.Write([]byte("cd"))
+	require.NoError(t, err)
+
+	require.Len(t, ch, 2)
+	p := <-ch
+	assert.Equal(t, uint64(2), p.Offset)
+	assert.InDelta(t, 50.0, p.Percent, 0.001)
+	p = <-ch
+	assert.Equal(t, uint64(4), p.Offset)
+	assert.InDelta(t, 100.0, p.Percent, 0.001)
+}
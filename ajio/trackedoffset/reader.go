@@ -21,30 +21,52 @@
 package trackedoffset
 
 import (
+	"bufio"
+	"errors"
 	"io"
 
 	"github.com/andrejacobs/go-aj/ajmath/safe"
 )
 
+// ResetToMark was called without a prior call to Mark.
+var ErrNoMark = errors.New("trackedoffset: no mark has been set")
+
 // Reader keeps track of the offset within an io.Reader source.
 type Reader struct {
 	rd     io.Reader
 	offset uint64
+
+	marked     bool
+	markOffset uint64 // the offset at the time Mark was called
+	sinceMark  uint64 // number of bytes read from a seekable source since Mark was called
+	replay     []byte // bytes read from a non-seekable source since Mark was called
+	replayPos  int    // position within replay from which the next Read will be served
+
+	lastByte     int  // the last byte read or -1 if UnreadByte is not possible
+	pushback     byte // the byte that was unread and not yet served from the replay buffer
+	hasPushback  bool // pushback will be served by the next Read
+	markPushback bool // a pushback byte was pending from a seekable source when Mark was called
+
+	progress *progressReporter
 }
 
 // Create a new Reader that will keep track of the offset within the source io.Reader.
 // baseOffset is the known starting offset.
 func NewReader(rd io.Reader, baseOffset uint64) *Reader {
 	r := &Reader{
-		rd:     rd,
-		offset: baseOffset,
+		rd:       rd,
+		offset:   baseOffset,
+		lastByte: -1,
 	}
 	return r
 }
 
 // Reader implementation.
 func (r *Reader) Read(p []byte) (int, error) {
-	n, err := r.rd.Read(p)
+	n, err := r.read(p)
+	if n > 0 {
+		r.lastByte = int(p[n-1])
+	}
 	if err != nil {
 		return n, err
 	}
@@ -54,10 +76,52 @@ func (r *Reader) Read(p []byte) (int, error) {
 		return 0, err
 	}
 	r.offset = newOffset
+	r.progress.update(r.offset)
 
 	return n, nil
 }
 
+// io.ByteReader.
+func (r *Reader) ReadByte() (byte, error) {
+	var b [1]byte
+	for {
+		n, err := r.Read(b[:])
+		if n == 1 {
+			return b[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// io.ByteScanner.
+// Unread the last byte read by Read or ReadByte and move the offset back by one.
+// Only the most recently read byte can be unread. Returns [bufio.ErrInvalidUnreadByte]
+// if no byte has been read since the last call to UnreadByte, Mark, ResetToMark or ClearMark.
+func (r *Reader) UnreadByte() error {
+	if r.lastByte < 0 {
+		return bufio.ErrInvalidUnreadByte
+	}
+
+	newOffset, err := safe.Sub64(r.offset, 1)
+	if err != nil {
+		return err
+	}
+
+	if r.replayPos > 0 {
+		// The byte is still kept in memory for replaying
+		r.replayPos--
+	} else {
+		r.pushback = byte(r.lastByte)
+		r.hasPushback = true
+	}
+
+	r.offset = newOffset
+	r.lastByte = -1
+	return nil
+}
+
 // Return the current offset in bytes.
 func (r *Reader) Offset() uint64 {
 	return r.offset
@@ -67,3 +131,112 @@ func (r *Reader) Offset() uint64 {
 func (r *Reader) ResetOffset(offset uint64) {
 	r.offset = offset
 }
+
+// Call fn every time the offset crosses a multiple of interval bytes or reaches total.
+// total is the expected size in bytes and can be 0 when it is not known.
+// Pass a nil fn to stop reporting progress.
+func (r *Reader) SetProgressFn(interval uint64, total uint64, fn ProgressFn) {
+	r.progress = newProgressReporter(interval, total, r.offset, fn)
+}
+
+// Mark the current position so that it can be returned to by calling ResetToMark.
+// If the source implements io.Seeker then ResetToMark will seek back to the mark,
+// otherwise the bytes read after the mark are kept in memory so that they can be replayed.
+// Calling Mark again will replace the previous mark.
+func (r *Reader) Mark() {
+	r.marked = true
+	r.markOffset = r.offset
+	r.sinceMark = 0
+	r.lastByte = -1
+
+	// Keep any bytes that still need to be replayed from a previous ResetToMark
+	r.replay = r.replay[r.replayPos:]
+	r.replayPos = 0
+
+	// A pending unread byte is already consumed from the source
+	r.markPushback = false
+	if r.hasPushback {
+		if _, ok := r.rd.(io.Seeker); ok {
+			r.markPushback = true
+		} else {
+			r.replay = append([]byte{r.pushback}, r.replay...)
+			r.hasPushback = false
+		}
+	}
+}
+
+// Return to the position that was recorded by the last call to Mark.
+// The mark remains set and thus ResetToMark can be called multiple times.
+// Returns [ErrNoMark] if Mark has not been called.
+func (r *Reader) ResetToMark() error {
+	if !r.marked {
+		return ErrNoMark
+	}
+
+	if seeker, ok := r.rd.(io.Seeker); ok {
+		sinceMark := r.sinceMark
+		if r.markPushback {
+			sinceMark++
+		}
+		delta, err := safe.Uint64ToInt64(sinceMark)
+		if err != nil {
+			return err
+		}
+		if _, err := seeker.Seek(-delta, io.SeekCurrent); err != nil {
+			return err
+		}
+		r.sinceMark = 0
+		r.markPushback = false
+	} else {
+		r.replayPos = 0
+	}
+
+	r.offset = r.markOffset
+	r.lastByte = -1
+	r.hasPushback = false
+	return nil
+}
+
+// Remove the mark and release any bytes that were kept in memory for replaying.
+func (r *Reader) ClearMark() {
+	r.marked = false
+	r.sinceMark = 0
+	r.markPushback = false
+	r.lastByte = -1
+	r.replay = r.replay[r.replayPos:]
+	r.replayPos = 0
+	if len(r.replay) == 0 {
+		r.replay = nil
+	}
+}
+
+// Read from the replay buffer first and then the source while recording what is needed to return to the mark.
+func (r *Reader) read(p []byte) (int, error) {
+	if r.hasPushback && len(p) > 0 {
+		// Serve the byte that was unread as if it came from the source
+		r.hasPushback = false
+		p[0] = r.pushback
+		return 1, nil
+	}
+
+	if r.replayPos < len(r.replay) {
+		n := copy(p, r.replay[r.replayPos:])
+		r.replayPos += n
+		if !r.marked && r.replayPos == len(r.replay) {
+			r.replay = nil
+			r.replayPos = 0
+		}
+		return n, nil
+	}
+
+	n, err := r.rd.Read(p)
+	if r.marked && n > 0 {
+		if _, ok := r.rd.(io.Seeker); ok {
+			r.sinceMark += uint64(n)
+		} else {
+			r.replay = append(r.replay, p[:n]...)
+			r.replayPos = len(r.replay)
+		}
+	}
+	return n, err
+}
@@ -22,9 +22,11 @@ package trackedoffset_test
 
 import (
 	"bufio"
+	"io"
 	"math"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/andrejacobs/go-aj/ajio/trackedoffset"
 	"github.com/andrejacobs/go-aj/ajmath/safe"
@@ -82,3 +84,156 @@ func TestReaderOverflow(t *testing.T) {
 	_, err := tr.Read(buffer)
 	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
 }
+
+func TestReaderMarkWithReplay(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+	br := bufio.NewReader(strings.NewReader(text))
+
+	tr := trackedoffset.NewReader(br, 0)
+	assert.ErrorIs(t, tr.ResetToMark(), trackedoffset.ErrNoMark)
+
+	buffer := make([]byte, 4)
+	_, err := tr.Read(buffer)
+	require.NoError(t, err)
+
+	tr.Mark()
+	_, err = io.ReadFull(tr, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "quic", string(buffer))
+	_, err = io.ReadFull(tr, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "k br", string(buffer))
+	assert.Equal(t, uint64(12), tr.Offset())
+
+	require.NoError(t, tr.ResetToMark())
+	assert.Equal(t, uint64(4), tr.Offset())
+
+	// Read across the end of the replayed bytes
+	big := make([]byte, 11)
+	_, err = io.ReadFull(tr, big)
+	require.NoError(t, err)
+	assert.Equal(t, "quick brown", string(big))
+	assert.Equal(t, uint64(15), tr.Offset())
+
+	// The mark is still valid
+	require.NoError(t, tr.ResetToMark())
+	assert.Equal(t, uint64(4), tr.Offset())
+
+	// Move the mark while replaying
+	_, err = io.ReadFull(tr, buffer)
+	require.NoError(t, err)
+	tr.Mark()
+	_, err = io.ReadFull(tr, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "k br", string(buffer))
+	require.NoError(t, tr.ResetToMark())
+	assert.Equal(t, uint64(8), tr.Offset())
+
+	tr.ClearMark()
+	assert.ErrorIs(t, tr.ResetToMark(), trackedoffset.ErrNoMark)
+
+	rest, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	assert.Equal(t, text[8:], string(rest))
+}
+
+func TestReaderMarkWithSeeker(t *testing.T) {
+	text := "The quick brown fox jumped over the lazy dog!"
+	sr := strings.NewReader(text)
+
+	tr := trackedoffset.NewReader(sr, 100)
+	buffer := make([]byte, 4)
+	_, err := tr.Read(buffer)
+	require.NoError(t, err)
+
+	tr.Mark()
+	_, err = tr.Read(buffer)
+	require.NoError(t, err)
+	_, err = tr.Read(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(112), tr.Offset())
+
+	require.NoError(t, tr.ResetToMark())
+	assert.Equal(t, uint64(104), tr.Offset())
+	assert.Equal(t, len(text)-4, sr.Len())
+
+	_, err = tr.Read(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "quic", string(buffer))
+}
+
+func TestReaderReadByteAndUnreadByte(t *testing.T) {
+	r := trackedoffset.NewReader(iotest.OneByteReader(strings.NewReader("abc")), 10)
+
+	assert.ErrorIs(t, r.UnreadByte(), bufio.ErrInvalidUnreadByte)
+
+	b, err := r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte('a'), b)
+	assert.Equal(t, uint64(11), r.Offset())
+
+	require.NoError(t, r.UnreadByte())
+	assert.Equal(t, uint64(10), r.Offset())
+	assert.ErrorIs(t, r.UnreadByte(), bufio.ErrInvalidUnreadByte)
+
+	b, err = r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte('a'), b)
+
+	p := make([]byte, 2)
+	n, err := io.ReadFull(r, p)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "bc", string(p))
+	assert.Equal(t, uint64(13), r.Offset())
+
+	// Unread the last byte of a Read
+	require.NoError(t, r.UnreadByte())
+	assert.Equal(t, uint64(12), r.Offset())
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "c", string(rest))
+
+	_, err = r.ReadByte()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReaderUnreadByteWithMark(t *testing.T) {
+	sources := map[string]func() io.Reader{
+		"replay": func() io.Reader { return iotest.OneByteReader(strings.NewReader("abcdef")) },
+		"seeker": func() io.Reader { return strings.NewReader("abcdef") },
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			r := trackedoffset.NewReader(source(), 0)
+
+			b, err := r.ReadByte()
+			require.NoError(t, err)
+			assert.Equal(t, byte('a'), b)
+			require.NoError(t, r.UnreadByte())
+
+			// Mark with a pending unread byte
+			r.Mark()
+			assert.ErrorIs(t, r.UnreadByte(), bufio.ErrInvalidUnreadByte)
+
+			p := make([]byte, 3)
+			_, err = io.ReadFull(r, p)
+			require.NoError(t, err)
+			assert.Equal(t, "abc", string(p))
+
+			require.NoError(t, r.UnreadByte())
+			b, err = r.ReadByte()
+			require.NoError(t, err)
+			assert.Equal(t, byte('c'), b)
+			assert.Equal(t, uint64(3), r.Offset())
+
+			require.NoError(t, r.ResetToMark())
+			assert.Equal(t, uint64(0), r.Offset())
+
+			rest, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, "abcdef", string(rest))
+		})
+	}
+}
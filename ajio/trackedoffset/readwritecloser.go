@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package trackedoffset
+
+import (
+	"io"
+	"sync/atomic"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+)
+
+// ReadWriteCloser keeps track of the read and write offsets of an io.ReadWriteCloser (e.g. a net.Conn).
+// Reading, writing and querying the offsets may be done from different goroutines.
+type ReadWriteCloser struct {
+	rwc         io.ReadWriteCloser
+	readOffset  atomic.Uint64
+	writeOffset atomic.Uint64
+}
+
+// Create a new ReadWriteCloser that will keep track of the number of bytes read from and written to rwc.
+// baseReadOffset and baseWriteOffset are the known starting offsets.
+func NewReadWriteCloser(rwc io.ReadWriteCloser, baseReadOffset uint64, baseWriteOffset uint64) *ReadWriteCloser {
+	t := &ReadWriteCloser{
+		rwc: rwc,
+	}
+	t.readOffset.Store(baseReadOffset)
+	t.writeOffset.Store(baseWriteOffset)
+	return t
+}
+
+// Reader implementation.
+func (t *ReadWriteCloser) Read(p []byte) (int, error) {
+	n, err := t.rwc.Read(p)
+	if err != nil {
+		return n, err
+	}
+
+	newOffset, err := safe.Add64(t.readOffset.Load(), uint64(n))
+	if err != nil {
+		return 0, err
+	}
+	t.readOffset.Store(newOffset)
+
+	return n, nil
+}
+
+// Writer implementation.
+func (t *ReadWriteCloser) Write(p []byte) (int, error) {
+	n, err := t.rwc.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	newOffset, err := safe.Add64(t.writeOffset.Load(), uint64(n))
+	if err != nil {
+		return 0, err
+	}
+	t.writeOffset.Store(newOffset)
+
+	return n, nil
+}
+
+// Close the underlying io.ReadWriteCloser.
+func (t *ReadWriteCloser) Close() error {
+	return t.rwc.Close()
+}
+
+// Return the current read offset in bytes.
+func (t *ReadWriteCloser) ReadOffset() uint64 {
+	return t.readOffset.Load()
+}
+
+// Return the current write offset in bytes.
+func (t *ReadWriteCloser) WriteOffset() uint64 {
+	return t.writeOffset.Load()
+}
+
+// Set the known read offset in bytes.
+func (t *ReadWriteCloser) ResetReadOffset(offset uint64) {
+	t.readOffset.Store(offset)
+}
+
+// Set the known write offset in bytes.
+func (t *ReadWriteCloser) ResetWriteOffset(offset uint64) {
+	t.writeOffset.Store(offset)
+}
+
+// Access the underlying io.ReadWriteCloser.
+func (t *ReadWriteCloser) Unwrap() io.ReadWriteCloser {
+	return t.rwc
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package trackedoffset_test
+
+import (
+	"io"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio/trackedoffset"
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWriteCloser(t *testing.T) {
+	client, server := net.Pipe()
+
+	tc := trackedoffset.NewReadWriteCloser(client, 0, 10)
+	ts := trackedoffset.NewReadWriteCloser(server, 0, 0)
+	assert.Equal(t, uint64(0), tc.ReadOffset())
+	assert.Equal(t, uint64(10), tc.WriteOffset())
+	assert.Equal(t, client, tc.Unwrap())
+
+	request := []byte("The quick brown fox")
+	response := []byte("jumped over the lazy dog!")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buffer := make([]byte, len(request))
+		_, err := io.ReadFull(ts, buffer)
+		assert.NoError(t, err)
+		assert.Equal(t, request, buffer)
+
+		_, err = ts.Write(response)
+		assert.NoError(t, err)
+	}()
+
+	_, err := tc.Write(request)
+	require.NoError(t, err)
+
+	buffer := make([]byte, len(response))
+	_, err = io.ReadFull(tc, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, response, buffer)
+	<-done
+
+	assert.Equal(t, uint64(len(response)), tc.ReadOffset())
+	assert.Equal(t, uint64(10+len(request)), tc.WriteOffset())
+	assert.Equal(t, uint64(len(request)), ts.ReadOffset())
+	assert.Equal(t, uint64(len(response)), ts.WriteOffset())
+
+	tc.ResetReadOffset(1)
+	tc.ResetWriteOffset(2)
+	assert.Equal(t, uint64(1), tc.ReadOffset())
+	assert.Equal(t, uint64(2), tc.WriteOffset())
+
+	require.NoError(t, tc.Close())
+	_, err = tc.Write(request)
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+	require.NoError(t, ts.Close())
+}
+
+func TestReadWriteCloserOverflow(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+
+	tc := trackedoffset.NewReadWriteCloser(client, 0, math.MaxUint64-2)
+	defer tc.Close()
+
+	go func() {
+		_, _ = io.Copy(io.Discard, server)
+	}()
+
+	_, err := tc.Write([]byte("overflow"))
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
@@ -30,6 +30,8 @@ import (
 type Writer struct {
 	wd     io.Writer
 	offset uint64
+
+	progress *progressReporter
 }
 
 // Create a new Writer that will keep track of the offset within the source io.Writer.
@@ -53,6 +55,7 @@ func (w *Writer) Write(p []byte) (int, error) {
 		return 0, err
 	}
 	w.offset = newOffset
+	w.progress.update(w.offset)
 
 	return n, nil
 }
@@ -66,3 +69,10 @@ func (w *Writer) Offset() uint64 {
 func (w *Writer) ResetOffset(offset uint64) {
 	w.offset = offset
 }
+
+// Call fn every time the offset crosses a multiple of interval bytes or reaches total.
+// total is the expected size in bytes and can be 0 when it is not known.
+// Pass a nil fn to stop reporting progress.
+func (w *Writer) SetProgressFn(interval uint64, total uint64, fn ProgressFn) {
+	w.progress = newProgressReporter(interval, total, w.offset, fn)
+}
@@ -27,6 +27,7 @@ import (
 	"io"
 	"math"
 
+	"github.com/andrejacobs/go-aj/ajio"
 	"golang.org/x/exp/constraints"
 )
 
@@ -48,6 +49,7 @@ type VariableDataFixedLen[S constraints.Unsigned] struct {
 	order    binary.ByteOrder
 	write    writeFunc
 	read     readFunc
+	pool     *ajio.BufferPool
 }
 
 // Create a new VariableData instance that will use 1 byte for the data prefix size.
@@ -88,6 +90,13 @@ func (v VariableDataFixedLen[S]) NativeEndian() VariableDataFixedLen[S] {
 	return v
 }
 
+// Use the BufferPool to get a buffer when the one provided to Read is not large enough to hold the data.
+// It is the caller's responsibility to Put the buffer returned from Read back into the pool once done.
+func (v VariableDataFixedLen[S]) WithBufferPool(pool *ajio.BufferPool) VariableDataFixedLen[S] {
+	v.pool = pool
+	return v
+}
+
 // Return the maximum number of bytes that can be read or written to for the data prefix.
 func (v VariableDataFixedLen[S]) MaxSize() S {
 	return v.maxValue
@@ -118,7 +127,7 @@ func (v VariableDataFixedLen[S]) Write(w io.Writer, data []byte) (int, error) {
 // A new buffer will be allocated if the provided one is not large enough to hold the data.
 // Returns the buffer and the number of bytes read including the size of the prefix.
 func (v VariableDataFixedLen[S]) Read(r io.Reader, buffer []byte) ([]byte, int, error) {
-	return v.read(r, buffer, v.order)
+	return v.read(r, buffer, v.order, v.pool)
 }
 
 // Write a string using the generic Write method to prefix the length of the string first and reducing allocs.
@@ -159,7 +168,11 @@ func (v VariableDataFixedLen[S]) ReadString(r io.Reader) (string, int, error) {
 		return "", 0, fmt.Errorf("failed to read a string. %w", err)
 	}
 
-	return string(data), rcount, err
+	s := string(data)
+	if v.pool != nil {
+		v.pool.Put(data)
+	}
+	return s, rcount, err
 }
 
 //-----------------------------------------------------------------------------
@@ -170,6 +183,7 @@ func (v VariableDataFixedLen[S]) ReadString(r io.Reader) (string, int, error) {
 // The default byte order is little endian.
 type VariableData struct {
 	order binary.ByteOrder
+	pool  *ajio.BufferPool
 }
 
 // Create a new VariableDataVarInt instance that will use between 1 and 10 bytes for the data prefix size.
@@ -182,6 +196,13 @@ func (v VariableData) ByteOrder() binary.ByteOrder {
 	return v.order
 }
 
+// Use the BufferPool to get a buffer when the one provided to Read is not large enough to hold the data.
+// It is the caller's responsibility to Put the buffer returned from Read back into the pool once done.
+func (v VariableData) WithBufferPool(pool *ajio.BufferPool) VariableData {
+	v.pool = pool
+	return v
+}
+
 // Write the size of the data (i.e len(data)) followed by that data itself.
 // Returns the number of bytes written including the size of the prefix.
 func (v VariableData) Write(w io.Writer, data []byte) (int, error) {
@@ -208,11 +229,7 @@ func (v VariableData) Read(r Reader, buffer []byte) ([]byte, int, error) {
 		return nil, varintSize, err
 	}
 
-	if cap(buffer) < int(dataLen) {
-		buffer = make([]byte, dataLen)
-	} else {
-		buffer = buffer[:dataLen]
-	}
+	buffer = resizeBuffer(buffer, int(dataLen), v.pool)
 
 	n, err := io.ReadFull(r, buffer)
 	if err != nil {
@@ -247,7 +264,11 @@ func (v VariableData) ReadString(r Reader) (string, int, error) {
 		return "", 0, fmt.Errorf("failed to read a string. %w", err)
 	}
 
-	return string(data), rcount, err
+	s := string(data)
+	if v.pool != nil {
+		v.pool.Put(data)
+	}
+	return s, rcount, err
 }
 
 // NOTE: Taken from encoding/binary/varint.go and modified to return the number of bytes read
@@ -284,7 +305,19 @@ var errOverflow = errors.New("binary: varint overflows a 64-bit integer")
 //-----------------------------------------------------------------------------
 
 type writeFunc func(w io.Writer, data []byte, count int, order binary.ByteOrder) (int, error)
-type readFunc func(r io.Reader, buffer []byte, order binary.ByteOrder) ([]byte, int, error)
+type readFunc func(r io.Reader, buffer []byte, order binary.ByteOrder, pool *ajio.BufferPool) ([]byte, int, error)
+
+// Return a slice of the buffer with the length of size if it has enough capacity,
+// else a new buffer is allocated (from the pool if one is provided).
+func resizeBuffer(buffer []byte, size int, pool *ajio.BufferPool) []byte {
+	if cap(buffer) >= size {
+		return buffer[:size]
+	}
+	if pool != nil {
+		return pool.Get(size)
+	}
+	return make([]byte, size)
+}
 
 func writeUint8(w io.Writer, data []byte, count int, order binary.ByteOrder) (int, error) {
 	if err := binary.Write(w, order, uint8(count)); err != nil {
@@ -322,17 +355,13 @@ func writeUint64(w io.Writer, data []byte, count int, order binary.ByteOrder) (i
 	return n + 8, err
 }
 
-func readUint8(r io.Reader, buffer []byte, order binary.ByteOrder) ([]byte, int, error) {
+func readUint8(r io.Reader, buffer []byte, order binary.ByteOrder, pool *ajio.BufferPool) ([]byte, int, error) {
 	var count uint8
 	if err := binary.Read(r, order, &count); err != nil {
 		return nil, 0, fmt.Errorf("failed to read the size of the data. %w", err)
 	}
 
-	if cap(buffer) < int(count) {
-		buffer = make([]byte, count)
-	} else {
-		buffer = buffer[:count]
-	}
+	buffer = resizeBuffer(buffer, int(count), pool)
 
 	n, err := io.ReadFull(r, buffer)
 	if err != nil {
@@ -342,17 +371,13 @@ func readUint8(r io.Reader, buffer []byte, order binary.ByteOrder) ([]byte, int,
 	return buffer, n + 1, nil
 }
 
-func readUint16(r io.Reader, buffer []byte, order binary.ByteOrder) ([]byte, int, error) {
+func readUint16(r io.Reader, buffer []byte, order binary.ByteOrder, pool *ajio.BufferPool) ([]byte, int, error) {
 	var count uint16
 	if err := binary.Read(r, order, &count); err != nil {
 		return nil, 0, fmt.Errorf("failed to read the size of the data. %w", err)
 	}
 
-	if cap(buffer) < int(count) {
-		buffer = make([]byte, count)
-	} else {
-		buffer = buffer[:count]
-	}
+	buffer = resizeBuffer(buffer, int(count), pool)
 
 	n, err := io.ReadFull(r, buffer)
 	if err != nil {
@@ -362,17 +387,13 @@ func readUint16(r io.Reader, buffer []byte, order binary.ByteOrder) ([]byte, int
 	return buffer, n + 2, nil
 }
 
-func readUint32(r io.Reader, buffer []byte, order binary.ByteOrder) ([]byte, int, error) {
+func readUint32(r io.Reader, buffer []byte, order binary.ByteOrder, pool *ajio.BufferPool) ([]byte, int, error) {
 	var count uint32
 	if err := binary.Read(r, order, &count); err != nil {
 		return nil, 0, fmt.Errorf("failed to read the size of the data. %w", err)
 	}
 
-	if cap(buffer) < int(count) {
-		buffer = make([]byte, count)
-	} else {
-		buffer = buffer[:count]
-	}
+	buffer = resizeBuffer(buffer, int(count), pool)
 
 	n, err := io.ReadFull(r, buffer)
 	if err != nil {
@@ -382,17 +403,13 @@ func readUint32(r io.Reader, buffer []byte, order binary.ByteOrder) ([]byte, int
 	return buffer, n + 4, nil
 }
 
-func readUint64(r io.Reader, buffer []byte, order binary.ByteOrder) ([]byte, int, error) {
+func readUint64(r io.Reader, buffer []byte, order binary.ByteOrder, pool *ajio.BufferPool) ([]byte, int, error) {
 	var count uint64
 	if err := binary.Read(r, order, &count); err != nil {
 		return nil, 0, fmt.Errorf("failed to read the size of the data. %w", err)
 	}
 
-	if cap(buffer) < int(count) {
-		buffer = make([]byte, count)
-	} else {
-		buffer = buffer[:count]
-	}
+	buffer = resizeBuffer(buffer, int(count), pool)
 
 	n, err := io.ReadFull(r, buffer)
 	if err != nil {
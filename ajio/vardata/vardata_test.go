@@ -26,6 +26,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/andrejacobs/go-aj/ajio"
 	"github.com/andrejacobs/go-aj/ajio/vardata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -302,3 +303,42 @@ func TestWriteAndReadStringVarInt(t *testing.T) {
 func samePointer(x, y interface{}) bool {
 	return reflect.ValueOf(x).Pointer() == reflect.ValueOf(y).Pointer()
 }
+
+func TestReadWithBufferPool(t *testing.T) {
+	pool := ajio.NewBufferPool()
+	expectedData := []byte("The quick brown fox")
+
+	buffer := bytes.Buffer{}
+	v := vardata.NewVariableDataUint16().WithBufferPool(pool)
+	_, err := v.Write(&buffer, expectedData)
+	require.NoError(t, err)
+	_, err = v.WriteString(&buffer, "jumped over")
+	require.NoError(t, err)
+
+	data, rcount, err := v.Read(&buffer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, len(expectedData)+v.PrefixSize(), rcount)
+	assert.Equal(t, expectedData, data)
+	assert.Equal(t, 64, cap(data))
+	pool.Put(data)
+
+	s, _, err := v.ReadString(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "jumped over", s)
+
+	vd := vardata.NewVariableData().WithBufferPool(pool)
+	_, err = vd.Write(&buffer, expectedData)
+	require.NoError(t, err)
+	_, err = vd.WriteString(&buffer, "the lazy dog")
+	require.NoError(t, err)
+
+	data, _, err = vd.Read(&buffer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expectedData, data)
+	assert.Equal(t, 64, cap(data))
+	pool.Put(data)
+
+	s, _, err = vd.ReadString(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "the lazy dog", s)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// Create an io.Reader that produces n zero bytes before returning io.EOF.
+func ZeroReader(n int64) io.Reader {
+	return io.LimitReader(zeros{}, n)
+}
+
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// CountingDiscard is an io.Writer on which all Write calls succeed without doing anything,
+// similar to [io.Discard], while keeping count of the number of bytes written.
+// The zero value is ready to be used and it is safe for concurrent use.
+type CountingDiscard struct {
+	count atomic.Int64
+}
+
+// io.Writer.
+func (d *CountingDiscard) Write(p []byte) (int, error) {
+	d.count.Add(int64(len(p)))
+	return len(p), nil
+}
+
+// io.StringWriter.
+func (d *CountingDiscard) WriteString(s string) (int, error) {
+	d.count.Add(int64(len(s)))
+	return len(s), nil
+}
+
+// io.ReaderFrom.
+func (d *CountingDiscard) ReadFrom(r io.Reader) (int64, error) {
+	n, err := io.Copy(io.Discard, r)
+	d.count.Add(n)
+	return n, err
+}
+
+// Return the number of bytes written.
+func (d *CountingDiscard) Count() int64 {
+	return d.count.Load()
+}
+
+// Reset the count to zero.
+func (d *CountingDiscard) Reset() {
+	d.count.Store(0)
+}
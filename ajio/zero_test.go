@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajio_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroReader(t *testing.T) {
+	data, err := io.ReadAll(ajio.ZeroReader(100))
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, 100), data)
+
+	buffer := bytes.Repeat([]byte{0xFF}, 10)
+	r := ajio.ZeroReader(4)
+	n, err := r.Read(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0xFF}, buffer[:5])
+
+	_, err = r.Read(buffer)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestCountingDiscard(t *testing.T) {
+	var d ajio.CountingDiscard
+
+	n, err := d.Write([]byte("The quick"))
+	require.NoError(t, err)
+	assert.Equal(t, 9, n)
+
+	n, err = io.WriteString(&d, " brown")
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+
+	copied, err := io.Copy(&d, strings.NewReader(" fox"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), copied)
+
+	copied, err = io.Copy(&d, ajio.ZeroReader(1000))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), copied)
+
+	assert.Equal(t, int64(1019), d.Count())
+	d.Reset()
+	assert.Equal(t, int64(0), d.Count())
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+)
+
+// The alignment is not a power of two.
+var ErrInvalidAlignment = errors.New("ajmath: alignment must be a power of two")
+
+// Return true if x is a power of two. 0 is not a power of two.
+func IsPow2(x uint64) bool {
+	return x != 0 && x&(x-1) == 0
+}
+
+// Return the smallest power of two that is greater than or equal to x.
+// NextPow2(0) returns 1.
+// Returns [safe.ErrIntegerOverflow] if the result would exceed [math.MaxUint64].
+func NextPow2(x uint64) (uint64, error) {
+	if x <= 1 {
+		return 1, nil
+	}
+
+	shift := bits.Len64(x - 1)
+	if shift >= 64 {
+		return 0, safe.ErrIntegerOverflow
+	}
+	return 1 << shift, nil
+}
+
+// Round x up to the next multiple of align, which must be a power of two.
+// Returns [ErrInvalidAlignment] if align is not a power of two.
+// Returns [safe.ErrIntegerOverflow] if the result would exceed [math.MaxUint64].
+func AlignUp(x, align uint64) (uint64, error) {
+	if !IsPow2(align) {
+		return 0, ErrInvalidAlignment
+	}
+
+	mask := align - 1
+	sum, err := safe.Add64(x, mask)
+	if err != nil {
+		return 0, err
+	}
+	return sum &^ mask, nil
+}
+
+// Round x down to the previous multiple of align, which must be a power of two.
+// Returns [ErrInvalidAlignment] if align is not a power of two.
+func AlignDown(x, align uint64) (uint64, error) {
+	if !IsPow2(align) {
+		return 0, ErrInvalidAlignment
+	}
+	return x &^ (align - 1), nil
+}
+
+// Return true if x is a multiple of align, which must be a power of two.
+// Returns [ErrInvalidAlignment] if align is not a power of two.
+func IsAligned(x, align uint64) (bool, error) {
+	if !IsPow2(align) {
+		return false, ErrInvalidAlignment
+	}
+	return x&(align-1) == 0, nil
+}
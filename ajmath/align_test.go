@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath"
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPow2(t *testing.T) {
+	assert.False(t, ajmath.IsPow2(0))
+	assert.True(t, ajmath.IsPow2(1))
+	assert.True(t, ajmath.IsPow2(4096))
+	assert.False(t, ajmath.IsPow2(4097))
+	assert.True(t, ajmath.IsPow2(1<<63))
+	assert.False(t, ajmath.IsPow2(math.MaxUint64))
+}
+
+func TestNextPow2(t *testing.T) {
+	testCases := []struct {
+		x        uint64
+		expected uint64
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{3, 4},
+		{1000, 1024},
+		{1024, 1024},
+		{1025, 2048},
+		{1 << 63, 1 << 63},
+	}
+
+	for _, tc := range testCases {
+		v, err := ajmath.NextPow2(tc.x)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, v, "NextPow2(%d)", tc.x)
+	}
+
+	_, err := ajmath.NextPow2(1<<63 + 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = ajmath.NextPow2(math.MaxUint64)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+func TestAlignUp(t *testing.T) {
+	v, err := ajmath.AlignUp(0, 4096)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), v)
+
+	v, err = ajmath.AlignUp(1, 4096)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4096), v)
+
+	v, err = ajmath.AlignUp(4096, 4096)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4096), v)
+
+	v, err = ajmath.AlignUp(math.MaxUint64-4095, 4096)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64-4095), v)
+
+	_, err = ajmath.AlignUp(math.MaxUint64-4094, 4096)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = ajmath.AlignUp(1, 3)
+	assert.ErrorIs(t, err, ajmath.ErrInvalidAlignment)
+
+	_, err = ajmath.AlignUp(1, 0)
+	assert.ErrorIs(t, err, ajmath.ErrInvalidAlignment)
+}
+
+func TestAlignDown(t *testing.T) {
+	v, err := ajmath.AlignDown(4097, 4096)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4096), v)
+
+	_, err = ajmath.AlignDown(1, 6)
+	assert.ErrorIs(t, err, ajmath.ErrInvalidAlignment)
+}
+
+func TestIsAligned(t *testing.T) {
+	ok, err := ajmath.IsAligned(8192, 4096)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = ajmath.IsAligned(8193, 4096)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = ajmath.IsAligned(1, 5)
+	assert.ErrorIs(t, err, ajmath.ErrInvalidAlignment)
+}
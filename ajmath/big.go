@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Return a + b as a big.Int which can not overflow.
+func AddBig(a, b uint64) *big.Int {
+	sum, carry := bits.Add64(a, b, 0)
+	return fromUint128(carry, sum)
+}
+
+// Return a * b as a big.Int which can not overflow.
+func MulBig(a, b uint64) *big.Int {
+	hi, lo := bits.Mul64(a, b)
+	return fromUint128(hi, lo)
+}
+
+func fromUint128(hi, lo uint64) *big.Int {
+	result := new(big.Int).SetUint64(lo)
+	if hi == 0 {
+		return result
+	}
+	high := new(big.Int).SetUint64(hi)
+	return result.Or(result, high.Lsh(high, 64))
+}
+
+// Accumulator sums unsigned 64bit values and transparently escalates to a [big.Int] once the
+// sum would overflow an uint64. The zero value is ready to use.
+type Accumulator struct {
+	sum uint64
+	big *big.Int // nil until the sum has overflowed
+}
+
+// Add n to the sum.
+func (a *Accumulator) Add(n uint64) {
+	if a.big != nil {
+		a.big.Add(a.big, new(big.Int).SetUint64(n))
+		return
+	}
+
+	sum, carry := bits.Add64(a.sum, n, 0)
+	if carry > 0 {
+		a.big = fromUint128(carry, sum)
+		return
+	}
+	a.sum = sum
+}
+
+// Return the sum as a uint64 and true if it fits, otherwise 0 and false.
+func (a *Accumulator) Uint64() (uint64, bool) {
+	if a.big != nil {
+		return 0, false
+	}
+	return a.sum, true
+}
+
+// Return a copy of the sum as a big.Int.
+func (a *Accumulator) Big() *big.Int {
+	if a.big != nil {
+		return new(big.Int).Set(a.big)
+	}
+	return new(big.Int).SetUint64(a.sum)
+}
+
+// Return true if the sum has exceeded [math.MaxUint64].
+func (a *Accumulator) Overflowed() bool {
+	return a.big != nil
+}
+
+// Return the sum as a base 10 string.
+func (a *Accumulator) String() string {
+	return a.Big().String()
+}
+
+// Reset the sum to 0.
+func (a *Accumulator) Reset() {
+	a.sum = 0
+	a.big = nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBig(t *testing.T) {
+	assert.Equal(t, "84", ajmath.AddBig(42, 42).String())
+	assert.Equal(t, "36893488147419103230", ajmath.AddBig(math.MaxUint64, math.MaxUint64).String())
+}
+
+func TestMulBig(t *testing.T) {
+	assert.Equal(t, "42", ajmath.MulBig(6, 7).String())
+
+	expected := new(big.Int).Mul(new(big.Int).SetUint64(math.MaxUint64), new(big.Int).SetUint64(math.MaxUint64))
+	assert.Equal(t, 0, expected.Cmp(ajmath.MulBig(math.MaxUint64, math.MaxUint64)))
+}
+
+func TestAccumulator(t *testing.T) {
+	var acc ajmath.Accumulator
+	acc.Add(math.MaxUint64 - 1)
+	acc.Add(1)
+
+	v, ok := acc.Uint64()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(math.MaxUint64), v)
+	assert.False(t, acc.Overflowed())
+
+	acc.Add(1)
+	assert.True(t, acc.Overflowed())
+	_, ok = acc.Uint64()
+	assert.False(t, ok)
+	assert.Equal(t, "18446744073709551616", acc.String())
+
+	acc.Add(math.MaxUint64)
+	assert.Equal(t, "36893488147419103231", acc.String())
+
+	// Big returns a copy
+	b := acc.Big()
+	b.SetInt64(0)
+	assert.Equal(t, "36893488147419103231", acc.String())
+
+	acc.Reset()
+	v, ok = acc.Uint64()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), v)
+}
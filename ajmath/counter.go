@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath
+
+import (
+	"sync/atomic"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+)
+
+// Counter is an unsigned 64bit counter that refuses to wrap around.
+// The zero value is ready to use. Counter is not safe for concurrent use, see [AtomicCounter].
+type Counter struct {
+	value uint64
+}
+
+// Add n to the counter.
+// Returns [safe.ErrIntegerOverflow] and leaves the counter unchanged if the result would overflow.
+func (c *Counter) Add(n uint64) error {
+	v, err := safe.Add64(c.value, n)
+	if err != nil {
+		return err
+	}
+	c.value = v
+	return nil
+}
+
+// Return the current value.
+func (c *Counter) Load() uint64 {
+	return c.value
+}
+
+// Reset the counter to 0 and return the previous value.
+func (c *Counter) Reset() uint64 {
+	old := c.value
+	c.value = 0
+	return old
+}
+
+// AtomicCounter is an unsigned 64bit counter that refuses to wrap around and is safe for concurrent use.
+// The zero value is ready to use.
+type AtomicCounter struct {
+	value atomic.Uint64
+}
+
+// Add n to the counter.
+// Returns [safe.ErrIntegerOverflow] and leaves the counter unchanged if the result would overflow.
+func (c *AtomicCounter) Add(n uint64) error {
+	for {
+		old := c.value.Load()
+		v, err := safe.Add64(old, n)
+		if err != nil {
+			return err
+		}
+		if c.value.CompareAndSwap(old, v) {
+			return nil
+		}
+	}
+}
+
+// Return the current value.
+func (c *AtomicCounter) Load() uint64 {
+	return c.value.Load()
+}
+
+// Reset the counter to 0 and return the previous value.
+func (c *AtomicCounter) Reset() uint64 {
+	return c.value.Swap(0)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath"
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounter(t *testing.T) {
+	var c ajmath.Counter
+	require.NoError(t, c.Add(math.MaxUint64-1))
+	require.NoError(t, c.Add(1))
+	assert.Equal(t, uint64(math.MaxUint64), c.Load())
+
+	assert.ErrorIs(t, c.Add(1), safe.ErrIntegerOverflow)
+	assert.Equal(t, uint64(math.MaxUint64), c.Load())
+
+	assert.Equal(t, uint64(math.MaxUint64), c.Reset())
+	assert.Equal(t, uint64(0), c.Load())
+}
+
+func TestAtomicCounter(t *testing.T) {
+	var c ajmath.AtomicCounter
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				assert.NoError(t, c.Add(1))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(10000), c.Load())
+
+	assert.ErrorIs(t, c.Add(math.MaxUint64), safe.ErrIntegerOverflow)
+	assert.Equal(t, uint64(10000), c.Load())
+
+	assert.Equal(t, uint64(10000), c.Reset())
+	assert.Equal(t, uint64(0), c.Load())
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath
+
+import "golang.org/x/exp/constraints"
+
+// Return the midpoint of two unsigned 64bit integers rounded down, without any intermediate overflow.
+func Mid64(a, b uint64) uint64 {
+	return a&b + (a^b)>>1
+}
+
+// Return the midpoint of two signed 64bit integers rounded towards negative infinity,
+// without any intermediate overflow.
+func MidInt64(a, b int64) int64 {
+	// Arithmetic shift keeps the sign
+	return a&b + (a^b)>>1
+}
+
+// Return the midpoint of two integers of the same type without any intermediate overflow.
+// The result is rounded towards negative infinity.
+func Mid[T constraints.Integer](a, b T) T {
+	return a&b + (a^b)>>1
+}
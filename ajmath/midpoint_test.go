@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMid64(t *testing.T) {
+	assert.Equal(t, uint64(5), ajmath.Mid64(0, 10))
+	assert.Equal(t, uint64(5), ajmath.Mid64(10, 0))
+	assert.Equal(t, uint64(5), ajmath.Mid64(5, 6))
+	assert.Equal(t, uint64(math.MaxUint64), ajmath.Mid64(math.MaxUint64, math.MaxUint64))
+	assert.Equal(t, uint64(math.MaxUint64-1), ajmath.Mid64(math.MaxUint64, math.MaxUint64-2))
+	assert.Equal(t, uint64(math.MaxUint64/2), ajmath.Mid64(0, math.MaxUint64))
+}
+
+func TestMidInt64(t *testing.T) {
+	assert.Equal(t, int64(0), ajmath.MidInt64(-10, 10))
+	assert.Equal(t, int64(-1), ajmath.MidInt64(-1, 0))
+	assert.Equal(t, int64(-1), ajmath.MidInt64(math.MinInt64, math.MaxInt64))
+	assert.Equal(t, int64(math.MaxInt64), ajmath.MidInt64(math.MaxInt64, math.MaxInt64))
+	assert.Equal(t, int64(math.MinInt64), ajmath.MidInt64(math.MinInt64, math.MinInt64))
+}
+
+func TestMidExhaustive8Bit(t *testing.T) {
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			expected := int8(math.Floor(float64(a+b) / 2))
+			assert.Equal(t, expected, ajmath.Mid(int8(a), int8(b)), "Mid(%d, %d)", a, b)
+		}
+	}
+
+	for a := 0; a <= math.MaxUint8; a++ {
+		for b := 0; b <= math.MaxUint8; b++ {
+			assert.Equal(t, uint8((a+b)/2), ajmath.Mid(uint8(a), uint8(b)), "Mid(%d, %d)", a, b)
+		}
+	}
+}
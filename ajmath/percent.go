@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+)
+
+// A division by zero was attempted.
+var ErrDivisionByZero = errors.New("ajmath: division by zero")
+
+// Rounding specifies how the result of an integer division is rounded.
+type Rounding int
+
+const (
+	RoundFloor  Rounding = iota // Round down (truncate)
+	RoundCeil                   // Round up
+	RoundHalfUp                 // Round to the nearest integer, halves are rounded up
+)
+
+// Return the percentage that part is of total. For example 50 out of 200 is 25.0.
+// Returns [ErrDivisionByZero] if total is 0.
+func Percent(part, total uint64) (float64, error) {
+	if total == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return float64(part) / float64(total) * 100, nil
+}
+
+// Return the percentage that part is of total as an integer rounded using the specified mode.
+// Returns [ErrDivisionByZero] if total is 0.
+// Returns [safe.ErrIntegerOverflow] if the result can not be represented by an uint64.
+func PercentRounded(part, total uint64, mode Rounding) (uint64, error) {
+	return MulDiv(part, 100, total, mode)
+}
+
+// Return the ratio of part to total scaled by scale and rounded using the specified mode.
+// For example a scale of 1000 returns the ratio in permille.
+// Returns [ErrDivisionByZero] if total is 0.
+// Returns [safe.ErrIntegerOverflow] if the result can not be represented by an uint64.
+func Ratio(part, total, scale uint64, mode Rounding) (uint64, error) {
+	return MulDiv(part, scale, total, mode)
+}
+
+// Return x * y / z rounded using the specified mode.
+// The intermediate product is calculated using 128 bits and thus does not overflow.
+// Returns [ErrDivisionByZero] if z is 0.
+// Returns [safe.ErrIntegerOverflow] if the result can not be represented by an uint64.
+func MulDiv(x, y, z uint64, mode Rounding) (uint64, error) {
+	if z == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	hi, lo := bits.Mul64(x, y)
+	if hi >= z {
+		return 0, safe.ErrIntegerOverflow
+	}
+	quo, rem := bits.Div64(hi, lo, z)
+
+	roundUp := false
+	switch mode {
+	case RoundCeil:
+		roundUp = rem > 0
+	case RoundHalfUp:
+		// rem >= z/2 without losing precision for odd z
+		roundUp = rem > 0 && rem >= z-rem
+	}
+
+	if roundUp {
+		if quo == math.MaxUint64 {
+			return 0, safe.ErrIntegerOverflow
+		}
+		quo++
+	}
+	return quo, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath"
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercent(t *testing.T) {
+	v, err := ajmath.Percent(50, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, v)
+
+	v, err = ajmath.Percent(300, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, 150.0, v)
+
+	_, err = ajmath.Percent(1, 0)
+	assert.ErrorIs(t, err, ajmath.ErrDivisionByZero)
+}
+
+func TestPercentRounded(t *testing.T) {
+	testCases := []struct {
+		part     uint64
+		total    uint64
+		mode     ajmath.Rounding
+		expected uint64
+	}{
+		{1, 3, ajmath.RoundFloor, 33},
+		{1, 3, ajmath.RoundCeil, 34},
+		{1, 3, ajmath.RoundHalfUp, 33},
+		{2, 3, ajmath.RoundFloor, 66},
+		{2, 3, ajmath.RoundCeil, 67},
+		{2, 3, ajmath.RoundHalfUp, 67},
+		{1, 200, ajmath.RoundHalfUp, 1},
+		{1, 200, ajmath.RoundFloor, 0},
+		{1, 201, ajmath.RoundHalfUp, 0},
+		{50, 100, ajmath.RoundCeil, 50},
+		{0, 100, ajmath.RoundCeil, 0},
+		{math.MaxUint64, math.MaxUint64, ajmath.RoundFloor, 100},
+		{math.MaxUint64 - 1, math.MaxUint64, ajmath.RoundFloor, 99},
+		{math.MaxUint64 - 1, math.MaxUint64, ajmath.RoundHalfUp, 100},
+	}
+
+	for _, tc := range testCases {
+		v, err := ajmath.PercentRounded(tc.part, tc.total, tc.mode)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, v, "%d/%d mode %d", tc.part, tc.total, tc.mode)
+	}
+
+	_, err := ajmath.PercentRounded(1, 0, ajmath.RoundFloor)
+	assert.ErrorIs(t, err, ajmath.ErrDivisionByZero)
+
+	_, err = ajmath.PercentRounded(math.MaxUint64, 1, ajmath.RoundFloor)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+func TestRatio(t *testing.T) {
+	v, err := ajmath.Ratio(1, 3, 1000, ajmath.RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(333), v)
+
+	v, err = ajmath.Ratio(1, 3, 1000, ajmath.RoundCeil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(334), v)
+}
+
+func TestMulDiv(t *testing.T) {
+	v, err := ajmath.MulDiv(math.MaxUint64, math.MaxUint64, math.MaxUint64, ajmath.RoundFloor)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), v)
+
+	_, err = ajmath.MulDiv(math.MaxUint64, 3, 2, ajmath.RoundFloor)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = ajmath.MulDiv(math.MaxUint64, math.MaxUint64-1, math.MaxUint64-1, ajmath.RoundFloor)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// The unit of time must be positive.
+var ErrInvalidUnit = errors.New("unit of time must be positive")
+
+// Convert a number of seconds to a [time.Duration].
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the duration can not be represented.
+func SecondsToDuration(seconds int64) (time.Duration, error) {
+	return CountToDuration(seconds, time.Second)
+}
+
+// Convert a number of milliseconds to a [time.Duration].
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the duration can not be represented.
+func MillisToDuration(millis int64) (time.Duration, error) {
+	return CountToDuration(millis, time.Millisecond)
+}
+
+// Convert a number of microseconds to a [time.Duration].
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the duration can not be represented.
+func MicrosToDuration(micros int64) (time.Duration, error) {
+	return CountToDuration(micros, time.Microsecond)
+}
+
+// Convert a count of the unit of time to a [time.Duration].
+// For example CountToDuration(uint64(1500), time.Millisecond).
+// Returns [ErrInvalidUnit] if unit is not positive.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the duration can not be represented.
+func CountToDuration[T constraints.Integer](count T, unit time.Duration) (time.Duration, error) {
+	if unit <= 0 {
+		return 0, ErrInvalidUnit
+	}
+
+	c, err := Cast[int64](count)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := MulInt64(c, int64(unit))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(d), nil
+}
+
+// Return the number of whole seconds in the duration as an integer of type T.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the result can not be represented by T.
+func DurationToSeconds[T constraints.Integer](d time.Duration) (T, error) {
+	return DurationToCount[T](d, time.Second)
+}
+
+// Return the number of whole milliseconds in the duration as an integer of type T.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the result can not be represented by T.
+func DurationToMillis[T constraints.Integer](d time.Duration) (T, error) {
+	return DurationToCount[T](d, time.Millisecond)
+}
+
+// Return the number of whole units of time in the duration as an integer of type T.
+// The result is truncated towards zero.
+// Returns [ErrInvalidUnit] if unit is not positive.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the result can not be represented by T.
+func DurationToCount[T constraints.Integer](d time.Duration, unit time.Duration) (T, error) {
+	if unit <= 0 {
+		return 0, ErrInvalidUnit
+	}
+	return Cast[T](int64(d / unit))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecondsToDuration(t *testing.T) {
+	d, err := safe.SecondsToDuration(90)
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+
+	// Roughly 292 years is the maximum
+	_, err = safe.SecondsToDuration(300 * 365 * 24 * 60 * 60)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.SecondsToDuration(-300 * 365 * 24 * 60 * 60)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestMillisToDuration(t *testing.T) {
+	d, err := safe.MillisToDuration(1500)
+	assert.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	_, err = safe.MillisToDuration(math.MaxInt64 / 1000)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+func TestMicrosToDuration(t *testing.T) {
+	d, err := safe.MicrosToDuration(-42)
+	assert.NoError(t, err)
+	assert.Equal(t, -42*time.Microsecond, d)
+
+	_, err = safe.MicrosToDuration(math.MinInt64)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestCountToDuration(t *testing.T) {
+	d, err := safe.CountToDuration(uint8(3), time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Hour, d)
+
+	_, err = safe.CountToDuration(uint64(math.MaxUint64), time.Nanosecond)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.CountToDuration(1, 0)
+	assert.ErrorIs(t, err, safe.ErrInvalidUnit)
+}
+
+func TestDurationToCount(t *testing.T) {
+	ms, err := safe.DurationToMillis[uint32](1500 * time.Microsecond)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), ms)
+
+	_, err = safe.DurationToMillis[uint32](-time.Second)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	s, err := safe.DurationToSeconds[int64](time.Duration(math.MaxInt64))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9223372036), s)
+
+	_, err = safe.DurationToSeconds[uint16](24 * time.Hour)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	m, err := safe.DurationToCount[int](90*time.Minute, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, m)
+
+	_, err = safe.DurationToCount[int](time.Hour, -time.Second)
+	assert.ErrorIs(t, err, safe.ErrInvalidUnit)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe
+
+import (
+	"errors"
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	// The floating point value is NaN (not a number).
+	ErrNaN = errors.New("floating point value is NaN")
+
+	// The floating point value is positive or negative infinity.
+	ErrInfinite = errors.New("floating point value is infinite")
+
+	// The floating point value has a fractional part.
+	ErrNotIntegral = errors.New("floating point value is not integral")
+)
+
+// Convert a 64bit floating point value to a signed 64bit integer by truncating towards zero.
+// Returns [ErrNaN] or [ErrInfinite] if x is not a finite number.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if x is out of range.
+func Float64ToInt64(x float64) (int64, error) {
+	return FloatToInt[int64](x)
+}
+
+// Convert a 64bit floating point value to an unsigned 64bit integer by truncating towards zero.
+// Returns [ErrNaN] or [ErrInfinite] if x is not a finite number.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if x is out of range.
+func Float64ToUint64(x float64) (uint64, error) {
+	return FloatToInt[uint64](x)
+}
+
+// Convert a 64bit floating point value to a signed 32bit integer by truncating towards zero.
+// Returns [ErrNaN] or [ErrInfinite] if x is not a finite number.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if x is out of range.
+func Float64ToInt32(x float64) (int32, error) {
+	return FloatToInt[int32](x)
+}
+
+// Convert a 64bit floating point value to an unsigned 32bit integer by truncating towards zero.
+// Returns [ErrNaN] or [ErrInfinite] if x is not a finite number.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if x is out of range.
+func Float64ToUint32(x float64) (uint32, error) {
+	return FloatToInt[uint32](x)
+}
+
+// Convert a 64bit floating point value to a platform dependant signed integer by truncating towards zero.
+// Returns [ErrNaN] or [ErrInfinite] if x is not a finite number.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if x is out of range.
+func Float64ToInt(x float64) (int, error) {
+	return FloatToInt[int](x)
+}
+
+// Convert a floating point value to any integer type by truncating towards zero.
+// Returns [ErrNaN] or [ErrInfinite] if x is not a finite number.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if x is out of range.
+func FloatToInt[T constraints.Integer, F constraints.Float](x F) (T, error) {
+	f := float64(x)
+	if math.IsNaN(f) {
+		return 0, ErrNaN
+	}
+	if math.IsInf(f, 0) {
+		return 0, ErrInfinite
+	}
+
+	// limit is 2^(N-1) for signed and 2^N for unsigned integers and is exactly representable
+	limit := float64(maxOf[T]()/2+1) * 2
+	t := math.Trunc(f)
+	if t >= limit {
+		return 0, ErrIntegerOverflow
+	}
+
+	if minOf[T]() < 0 {
+		if t < -limit {
+			return 0, ErrIntegerUnderflow
+		}
+	} else if t < 0 {
+		return 0, ErrIntegerUnderflow
+	}
+
+	return T(t), nil
+}
+
+// Convert a floating point value to any integer type.
+// Returns [ErrNotIntegral] if x has a fractional part.
+// Returns [ErrNaN] or [ErrInfinite] if x is not a finite number.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if x is out of range.
+func FloatToIntExact[T constraints.Integer, F constraints.Float](x F) (T, error) {
+	v, err := FloatToInt[T](x)
+	if err != nil {
+		return v, err
+	}
+	if float64(x) != math.Trunc(float64(x)) {
+		return 0, ErrNotIntegral
+	}
+	return v, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloat64ToInt64(t *testing.T) {
+	v, err := safe.Float64ToInt64(-42.9)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-42), v)
+
+	v, err = safe.Float64ToInt64(math.MinInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MinInt64), v)
+
+	// float64(MaxInt64) rounds up to 2^63
+	_, err = safe.Float64ToInt64(math.MaxInt64)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v, err = safe.Float64ToInt64(math.Nextafter(1<<63, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1<<63-1024), v)
+
+	_, err = safe.Float64ToInt64(math.Nextafter(math.MinInt64, math.Inf(-1)))
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.Float64ToInt64(math.NaN())
+	assert.ErrorIs(t, err, safe.ErrNaN)
+
+	_, err = safe.Float64ToInt64(math.Inf(1))
+	assert.ErrorIs(t, err, safe.ErrInfinite)
+
+	_, err = safe.Float64ToInt64(math.Inf(-1))
+	assert.ErrorIs(t, err, safe.ErrInfinite)
+}
+
+func TestFloat64ToUint64(t *testing.T) {
+	v, err := safe.Float64ToUint64(42.9)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), v)
+
+	v, err = safe.Float64ToUint64(-0.9)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), v)
+
+	_, err = safe.Float64ToUint64(-1)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.Float64ToUint64(1 << 64)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v, err = safe.Float64ToUint64(math.Nextafter(1<<64, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1<<64-2048), v)
+}
+
+func TestFloat64ToInt32(t *testing.T) {
+	v, err := safe.Float64ToInt32(math.MaxInt32 + 0.5)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(math.MaxInt32), v)
+
+	_, err = safe.Float64ToInt32(math.MaxInt32 + 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.Float64ToInt32(math.MinInt32 - 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestFloat64ToUint32(t *testing.T) {
+	v, err := safe.Float64ToUint32(math.MaxUint32)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(math.MaxUint32), v)
+
+	_, err = safe.Float64ToUint32(math.MaxUint32 + 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+func TestFloat64ToInt(t *testing.T) {
+	v, err := safe.Float64ToInt(-1e6)
+	assert.NoError(t, err)
+	assert.Equal(t, -1000000, v)
+}
+
+func TestFloatToIntExact(t *testing.T) {
+	v, err := safe.FloatToIntExact[uint8](float32(255))
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), v)
+
+	_, err = safe.FloatToIntExact[uint8](254.5)
+	assert.ErrorIs(t, err, safe.ErrNotIntegral)
+
+	_, err = safe.FloatToIntExact[uint8](256.0)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.FloatToIntExact[int8](math.NaN())
+	assert.ErrorIs(t, err, safe.ErrNaN)
+}
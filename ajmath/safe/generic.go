@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe
+
+import "golang.org/x/exp/constraints"
+
+// Cast x from any integer type to any other integer type.
+// Returns [ErrIntegerUnderflow] if x is too small to be represented by To.
+// Returns [ErrIntegerOverflow] if x is too big to be represented by To.
+func Cast[To, From constraints.Integer](x From) (To, error) {
+	result := To(x)
+	// The value is preserved only when converting back yields x and the sign did not change
+	if From(result) != x || (x < 0) != (result < 0) {
+		if x < 0 {
+			return 0, ErrIntegerUnderflow
+		}
+		return 0, ErrIntegerOverflow
+	}
+	return result, nil
+}
+
+// Add two integers of the same type.
+// Returns [ErrIntegerOverflow] if the result is too big to be represented by T.
+// Returns [ErrIntegerUnderflow] if the result is too small to be represented by T.
+func Add[T constraints.Integer](x, y T) (T, error) {
+	result := x + y
+	if y > 0 && result < x {
+		return 0, ErrIntegerOverflow
+	} else if y < 0 && result > x {
+		return 0, ErrIntegerUnderflow
+	}
+	return result, nil
+}
+
+// Subtract y from x where both are integers of the same type.
+// Returns [ErrIntegerOverflow] if the result is too big to be represented by T.
+// Returns [ErrIntegerUnderflow] if the result is too small to be represented by T.
+func Sub[T constraints.Integer](x, y T) (T, error) {
+	result := x - y
+	if y > 0 && result > x {
+		return 0, ErrIntegerUnderflow
+	} else if y < 0 && result < x {
+		return 0, ErrIntegerOverflow
+	}
+	return result, nil
+}
+
+// Multiply two integers of the same type.
+// Returns [ErrIntegerOverflow] if the result is too big to be represented by T.
+// Returns [ErrIntegerUnderflow] if the result is too small to be represented by T.
+func Mul[T constraints.Integer](x, y T) (T, error) {
+	if x == 0 || y == 0 {
+		return 0, nil
+	}
+
+	result := x * y
+	negative := (x < 0) != (y < 0)
+	// The sign check catches the MinInt * -1 case for which the division check does not work
+	if result/y != x || (result < 0) != negative {
+		if negative {
+			return 0, ErrIntegerUnderflow
+		}
+		return 0, ErrIntegerOverflow
+	}
+	return result, nil
+}
+
+// Return the sum of all the values.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] as soon as a partial sum can not be represented by T.
+func Sum[T constraints.Integer](values []T) (T, error) {
+	var sum T
+	for _, v := range values {
+		var err error
+		sum, err = Add(sum, v)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
+// Return the sum of all the unsigned values accumulated as an unsigned 64bit integer.
+// This allows for example the sum of many uint32 values to exceed [math.MaxUint32].
+// Returns [ErrIntegerOverflow] if the sum exceeds [math.MaxUint64].
+func SumUint64[T constraints.Unsigned](values []T) (uint64, error) {
+	var sum uint64
+	for _, v := range values {
+		var err error
+		sum, err = Add64(sum, uint64(v))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCast(t *testing.T) {
+	v16, err := safe.Cast[uint16](int32(math.MaxUint16))
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(math.MaxUint16), v16)
+
+	v16, err = safe.Cast[uint16](int32(math.MaxUint16 + 1))
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, uint16(0), v16)
+
+	_, err = safe.Cast[uint16](int32(-1))
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	v8, err := safe.Cast[int8](int64(math.MinInt8))
+	assert.NoError(t, err)
+	assert.Equal(t, int8(math.MinInt8), v8)
+
+	_, err = safe.Cast[int8](int64(math.MinInt8 - 1))
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.Cast[int8](uint8(128))
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.Cast[int64](uint64(math.MaxUint64))
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v64, err := safe.Cast[uint64](int64(math.MaxInt64))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxInt64), v64)
+
+	_, err = safe.Cast[uint64](int8(-1))
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	vi64, err := safe.Cast[int64](int8(-128))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-128), vi64)
+
+	vu, err := safe.Cast[uint](uint32(math.MaxUint32))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(math.MaxUint32), vu)
+}
+
+func TestCastExhaustive8Bit(t *testing.T) {
+	for i := math.MinInt16; i <= math.MaxInt16; i++ {
+		x := int16(i)
+
+		v, err := safe.Cast[int8](x)
+		if x >= math.MinInt8 && x <= math.MaxInt8 {
+			assert.NoError(t, err)
+			assert.Equal(t, int8(x), v)
+		} else {
+			assert.Error(t, err)
+		}
+
+		u, err := safe.Cast[uint8](x)
+		if x >= 0 && x <= math.MaxUint8 {
+			assert.NoError(t, err)
+			assert.Equal(t, uint8(x), u)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	v, err := safe.Add[uint8](200, 55)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), v)
+
+	_, err = safe.Add[uint8](200, 56)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v8, err := safe.Add[int8](-100, -28)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), v8)
+
+	_, err = safe.Add[int8](-100, -29)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.Add[int8](100, 28)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v64, err := safe.Add[int64](math.MaxInt64, math.MinInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), v64)
+}
+
+func TestSub(t *testing.T) {
+	v, err := safe.Sub[uint16](10, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0), v)
+
+	_, err = safe.Sub[uint16](10, 11)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	v8, err := safe.Sub[int8](-100, 28)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), v8)
+
+	_, err = safe.Sub[int8](-100, 29)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.Sub[int8](0, math.MinInt8)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v32, err := safe.Sub[int32](-1, math.MinInt32)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(math.MaxInt32), v32)
+}
+
+func TestMul(t *testing.T) {
+	v, err := safe.Mul[uint8](15, 17)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), v)
+
+	_, err = safe.Mul[uint8](16, 16)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v8, err := safe.Mul[int8](-64, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), v8)
+
+	_, err = safe.Mul[int8](-64, 3)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.Mul[int8](math.MinInt8, -1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.Mul[int8](-1, math.MinInt8)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v64, err := safe.Mul[int64](0, math.MinInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), v64)
+}
+
+func TestArithmeticExhaustive8Bit(t *testing.T) {
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			check := func(name string, expected int, v int8, err error) {
+				if expected < math.MinInt8 {
+					assert.ErrorIs(t, err, safe.ErrIntegerUnderflow, "%s(%d, %d)", name, a, b)
+				} else if expected > math.MaxInt8 {
+					assert.ErrorIs(t, err, safe.ErrIntegerOverflow, "%s(%d, %d)", name, a, b)
+				} else {
+					assert.NoError(t, err, "%s(%d, %d)", name, a, b)
+					assert.Equal(t, int8(expected), v, "%s(%d, %d)", name, a, b)
+				}
+			}
+
+			v, err := safe.Add(int8(a), int8(b))
+			check("Add", a+b, v, err)
+			v, err = safe.Sub(int8(a), int8(b))
+			check("Sub", a-b, v, err)
+			v, err = safe.Mul(int8(a), int8(b))
+			check("Mul", a*b, v, err)
+		}
+	}
+}
+
+func TestSum(t *testing.T) {
+	v, err := safe.Sum([]int64{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), v)
+
+	v, err = safe.Sum([]int64{1, 2, 3, -10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-4), v)
+
+	_, err = safe.Sum([]int64{math.MaxInt64, 1, -1})
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.Sum([]int8{-100, -100})
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.Sum([]uint32{math.MaxUint32, 1})
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+func TestSumUint64(t *testing.T) {
+	v, err := safe.SumUint64([]uint32{math.MaxUint32, math.MaxUint32, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1<<33), v)
+
+	_, err = safe.SumUint64([]uint64{math.MaxUint64, 1})
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Return v or panic if err is not nil.
+// For example: safe.Must(safe.Add[int16](x, y)).
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(fmt.Sprintf("safe: %v", err))
+	}
+	return v
+}
+
+// Add two unsigned 32bit integers and panic if an overflow occurred.
+func MustAdd32(x, y uint32) uint32 {
+	return mustBinary("Add32", x, y)(Add32(x, y))
+}
+
+// Add two unsigned 64bit integers and panic if an overflow occurred.
+func MustAdd64(x, y uint64) uint64 {
+	return mustBinary("Add64", x, y)(Add64(x, y))
+}
+
+// Subtract two unsigned 32bit integers and panic if an underflow occurred.
+func MustSub32(x, y uint32) uint32 {
+	return mustBinary("Sub32", x, y)(Sub32(x, y))
+}
+
+// Subtract two unsigned 64bit integers and panic if an underflow occurred.
+func MustSub64(x, y uint64) uint64 {
+	return mustBinary("Sub64", x, y)(Sub64(x, y))
+}
+
+// Multiply two unsigned 32bit integers and panic if an overflow occurred.
+func MustMul32(x, y uint32) uint32 {
+	return mustBinary("Mul32", x, y)(Mul32(x, y))
+}
+
+// Multiply two unsigned 64bit integers and panic if an overflow occurred.
+func MustMul64(x, y uint64) uint64 {
+	return mustBinary("Mul64", x, y)(Mul64(x, y))
+}
+
+// Cast x from any integer type to any other integer type and panic if the value can not be represented.
+func MustCast[To, From constraints.Integer](x From) To {
+	v, err := Cast[To](x)
+	if err != nil {
+		panic(fmt.Sprintf("safe: Cast[%T](%T(%d)): %v", v, x, x, err))
+	}
+	return v
+}
+
+// Return a function that panics with a message describing the operation if the error is not nil.
+func mustBinary[T constraints.Integer](op string, x, y T) func(T, error) T {
+	return func(v T, err error) T {
+		if err != nil {
+			panic(fmt.Sprintf("safe: %s(%d, %d): %v", op, x, y, err))
+		}
+		return v
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMust(t *testing.T) {
+	assert.Equal(t, int16(42), safe.Must(safe.Add[int16](40, 2)))
+	assert.PanicsWithValue(t, "safe: integer overflow occurred", func() {
+		safe.Must(safe.Add[int16](math.MaxInt16, 1))
+	})
+}
+
+func TestMustArithmetic(t *testing.T) {
+	assert.Equal(t, uint32(84), safe.MustAdd32(42, 42))
+	assert.Equal(t, uint64(84), safe.MustAdd64(42, 42))
+	assert.Equal(t, uint32(0), safe.MustSub32(42, 42))
+	assert.Equal(t, uint64(0), safe.MustSub64(42, 42))
+	assert.Equal(t, uint32(42), safe.MustMul32(6, 7))
+	assert.Equal(t, uint64(42), safe.MustMul64(6, 7))
+
+	assert.PanicsWithValue(t, "safe: Add32(4294967295, 1): integer overflow occurred", func() {
+		safe.MustAdd32(math.MaxUint32, 1)
+	})
+	assert.Panics(t, func() { safe.MustAdd64(math.MaxUint64, 1) })
+	assert.PanicsWithValue(t, "safe: Sub32(1, 2): integer underflow occurred", func() {
+		safe.MustSub32(1, 2)
+	})
+	assert.Panics(t, func() { safe.MustSub64(1, 2) })
+	assert.Panics(t, func() { safe.MustMul32(1<<16, 1<<16) })
+	assert.Panics(t, func() { safe.MustMul64(1<<32, 1<<32) })
+}
+
+func TestMustCast(t *testing.T) {
+	assert.Equal(t, uint8(255), safe.MustCast[uint8](int64(255)))
+	assert.PanicsWithValue(t, "safe: Cast[uint8](int64(256)): integer overflow occurred", func() {
+		safe.MustCast[uint8](int64(256))
+	})
+	assert.Panics(t, func() { safe.MustCast[uint8](-1) })
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Parse a base 10 string as a signed 8bit integer.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the value is out of range.
+func ParseInt8(s string) (int8, error) {
+	return Parse[int8](s, 10)
+}
+
+// Parse a base 10 string as a signed 16bit integer.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the value is out of range.
+func ParseInt16(s string) (int16, error) {
+	return Parse[int16](s, 10)
+}
+
+// Parse a base 10 string as a signed 32bit integer.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the value is out of range.
+func ParseInt32(s string) (int32, error) {
+	return Parse[int32](s, 10)
+}
+
+// Parse a base 10 string as an unsigned 8bit integer.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the value is out of range.
+func ParseUint8(s string) (uint8, error) {
+	return Parse[uint8](s, 10)
+}
+
+// Parse a base 10 string as an unsigned 16bit integer.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the value is out of range.
+func ParseUint16(s string) (uint16, error) {
+	return Parse[uint16](s, 10)
+}
+
+// Parse a base 10 string as an unsigned 32bit integer.
+// Returns [ErrIntegerOverflow] or [ErrIntegerUnderflow] if the value is out of range.
+func ParseUint32(s string) (uint32, error) {
+	return Parse[uint32](s, 10)
+}
+
+// Parse a string in the given base as an integer of type T. See [strconv.ParseInt] for the meaning of base.
+// Returns [ErrIntegerOverflow] if the value is too big to be represented by T.
+// Returns [ErrIntegerUnderflow] if the value is too small to be represented by T, including negative
+// values for unsigned types.
+// Syntax errors are returned as is from the strconv package.
+func Parse[T constraints.Integer](s string, base int) (T, error) {
+	bitSize := int(unsafe.Sizeof(T(0)) * 8)
+
+	if minOf[T]() < 0 {
+		v, err := strconv.ParseInt(s, base, bitSize)
+		if err != nil {
+			return 0, parseError(s, err)
+		}
+		return T(v), nil
+	}
+
+	if strings.HasPrefix(s, "-") {
+		// strconv reports a syntax error for a negative unsigned value
+		v, err := strconv.ParseInt(s, base, 64)
+		if (err == nil && v < 0) || errors.Is(err, strconv.ErrRange) {
+			return 0, ErrIntegerUnderflow
+		} else if err == nil {
+			// -0
+			return 0, nil
+		}
+	}
+
+	v, err := strconv.ParseUint(s, base, bitSize)
+	if err != nil {
+		return 0, parseError(s, err)
+	}
+	return T(v), nil
+}
+
+// Map a strconv range error to the package's overflow or underflow error.
+func parseError(s string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		if strings.HasPrefix(s, "-") {
+			return ErrIntegerUnderflow
+		}
+		return ErrIntegerOverflow
+	}
+	return err
+}
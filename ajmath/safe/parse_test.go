@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInt(t *testing.T) {
+	v8, err := safe.ParseInt8("-128")
+	assert.NoError(t, err)
+	assert.Equal(t, int8(math.MinInt8), v8)
+
+	_, err = safe.ParseInt8("128")
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.ParseInt8("-129")
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	v16, err := safe.ParseInt16("32767")
+	assert.NoError(t, err)
+	assert.Equal(t, int16(math.MaxInt16), v16)
+
+	_, err = safe.ParseInt16("-32769")
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	v32, err := safe.ParseInt32("-2147483648")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(math.MinInt32), v32)
+
+	_, err = safe.ParseInt32("2147483648")
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.ParseInt32("42x")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+func TestParseUint(t *testing.T) {
+	v8, err := safe.ParseUint8("255")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(math.MaxUint8), v8)
+
+	_, err = safe.ParseUint8("256")
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.ParseUint8("-1")
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.ParseUint8("-99999999999999999999999")
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	v8, err = safe.ParseUint8("-0")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0), v8)
+
+	_, err = safe.ParseUint8("-")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	v16, err := safe.ParseUint16("65535")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(math.MaxUint16), v16)
+
+	v32, err := safe.ParseUint32("4294967295")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(math.MaxUint32), v32)
+
+	_, err = safe.ParseUint32("4294967296")
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+func TestParse(t *testing.T) {
+	v, err := safe.Parse[uint16]("ffff", 16)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0xFFFF), v)
+
+	v64, err := safe.Parse[uint64]("0x10", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16), v64)
+
+	_, err = safe.Parse[int64]("9223372036854775808", 10)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.Parse[uint64]("18446744073709551616", 10)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
@@ -78,6 +78,44 @@ func Sub64(x, y uint64) (uint64, error) {
 	return new, nil
 }
 
+// Multiply two unsigned 32bit integers.
+// Returns [ErrIntegerOverflow] if an overflow occurred.
+func Mul32(x, y uint32) (uint32, error) {
+	hi, lo := bits.Mul32(x, y)
+	if hi > 0 {
+		return 0, ErrIntegerOverflow
+	}
+	return lo, nil
+}
+
+// Multiply two unsigned 64bit integers.
+// Returns [ErrIntegerOverflow] if an overflow occurred.
+func Mul64(x, y uint64) (uint64, error) {
+	hi, lo := bits.Mul64(x, y)
+	if hi > 0 {
+		return 0, ErrIntegerOverflow
+	}
+	return lo, nil
+}
+
+// Shift an unsigned 32bit integer left by n bits.
+// Returns [ErrIntegerOverflow] if any set bits would be shifted out or n is 32 or more.
+func Shl32(x uint32, n uint) (uint32, error) {
+	if n >= 32 || uint(bits.LeadingZeros32(x)) < n {
+		return 0, ErrIntegerOverflow
+	}
+	return x << n, nil
+}
+
+// Shift an unsigned 64bit integer left by n bits.
+// Returns [ErrIntegerOverflow] if any set bits would be shifted out or n is 64 or more.
+func Shl64(x uint64, n uint) (uint64, error) {
+	if n >= 64 || uint(bits.LeadingZeros64(x)) < n {
+		return 0, ErrIntegerOverflow
+	}
+	return x << n, nil
+}
+
 //-----------------------------------------------------------------------------
 // Safe casting
 
@@ -314,3 +352,42 @@ func Uint64ToInt(x uint64) (int, error) {
 
 	return int(x), nil
 }
+
+// Cast from platform dependant signed integer to platform dependant unsigned integer.
+// Return [ErrIntegerUnderflow] if x is negative.
+func IntToUint(x int) (uint, error) {
+	if x < 0 {
+		return 0, ErrIntegerUnderflow
+	}
+	return uint(x), nil
+}
+
+// Cast from platform dependant unsigned integer to platform dependant signed integer.
+// Return [ErrIntegerOverflow] if x is too big.
+func UintToInt(x uint) (int, error) {
+	if x > math.MaxInt {
+		return 0, ErrIntegerOverflow
+	}
+	return int(x), nil
+}
+
+// Cast from signed 64bit integer to platform dependant signed integer.
+// Return [ErrIntegerUnderflow] if x is too small.
+// Return [ErrIntegerOverflow] if x is too big.
+func Int64ToInt(x int64) (int, error) {
+	if (IntSize == 32) && (x < math.MinInt32) {
+		return 0, ErrIntegerUnderflow
+	} else if (IntSize == 32) && (x > math.MaxInt32) {
+		return 0, ErrIntegerOverflow
+	}
+	return int(x), nil
+}
+
+// Cast from unsigned 64bit integer to platform dependant unsigned integer.
+// Return [ErrIntegerOverflow] if x is too big.
+func Uint64ToUint(x uint64) (uint, error) {
+	if (IntSize == 32) && (x > math.MaxUint32) {
+		return 0, ErrIntegerOverflow
+	}
+	return uint(x), nil
+}
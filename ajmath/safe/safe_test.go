@@ -69,6 +69,71 @@ func TestSub64(t *testing.T) {
 	assert.Equal(t, uint64(0), v)
 }
 
+func TestMul32(t *testing.T) {
+	v, err := safe.Mul32(6, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(42), v)
+
+	v, err = safe.Mul32(math.MaxUint32, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(math.MaxUint32), v)
+
+	v, err = safe.Mul32(1<<16, 1<<16)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, uint32(0), v)
+}
+
+func TestMul64(t *testing.T) {
+	v, err := safe.Mul64(6, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), v)
+
+	v, err = safe.Mul64(math.MaxUint64, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), v)
+
+	v, err = safe.Mul64(1<<32, 1<<32)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, uint64(0), v)
+}
+
+func TestShl32(t *testing.T) {
+	v, err := safe.Shl32(1, 31)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1<<31), v)
+
+	v, err = safe.Shl32(0, 31)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), v)
+
+	_, err = safe.Shl32(0, 32)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	v, err = safe.Shl32(2, 31)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, uint32(0), v)
+
+	_, err = safe.Shl32(1, 32)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+func TestShl64(t *testing.T) {
+	v, err := safe.Shl64(0xFF, 56)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0xFF<<56), v)
+
+	v, err = safe.Shl64(42, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), v)
+
+	v, err = safe.Shl64(0xFF, 57)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, uint64(0), v)
+
+	_, err = safe.Shl64(1, 64)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
 //-----------------------------------------------------------------------------
 // Casting
 
@@ -480,3 +545,63 @@ func TestUint64ToInt(t *testing.T) {
 		assert.Equal(t, 0, v)
 	}
 }
+
+func TestIntToUint(t *testing.T) {
+	v, err := safe.IntToUint(math.MaxInt)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(math.MaxInt), v)
+
+	v, err = safe.IntToUint(-1)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+	assert.Equal(t, uint(0), v)
+}
+
+func TestUintToInt(t *testing.T) {
+	v, err := safe.UintToInt(math.MaxInt)
+	assert.NoError(t, err)
+	assert.Equal(t, math.MaxInt, v)
+
+	v, err = safe.UintToInt(math.MaxInt + 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, 0, v)
+}
+
+func TestInt64ToInt(t *testing.T) {
+	v, err := safe.Int64ToInt(math.MinInt32)
+	assert.NoError(t, err)
+	assert.Equal(t, math.MinInt32, v)
+
+	if safe.IntSize == 32 {
+		// 32 bit machine
+		v, err = safe.Int64ToInt(math.MaxInt32 + 1)
+		assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+		assert.Equal(t, 0, v)
+
+		v, err = safe.Int64ToInt(math.MinInt32 - 1)
+		assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+		assert.Equal(t, 0, v)
+	} else {
+		// 64 bit machine
+		v, err = safe.Int64ToInt(math.MinInt)
+		assert.NoError(t, err)
+		assert.Equal(t, math.MinInt, v)
+	}
+}
+
+func TestUint64ToUint(t *testing.T) {
+	v, err := safe.Uint64ToUint(math.MaxUint32)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(math.MaxUint32), v)
+
+	if safe.IntSize == 32 {
+		// 32 bit machine
+		v, err = safe.Uint64ToUint(math.MaxUint32 + 1)
+		assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+		assert.Equal(t, uint(0), v)
+	} else {
+		// 64 bit machine
+		v, err = safe.Uint64ToUint(math.MaxUint)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(math.MaxUint), v)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe
+
+import (
+	"math"
+	"math/bits"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Add two unsigned 64bit integers.
+// Returns [math.MaxUint64] instead of overflowing.
+func SatAdd64(x, y uint64) uint64 {
+	sum, carry := bits.Add64(x, y, 0)
+	if carry > 0 {
+		return math.MaxUint64
+	}
+	return sum
+}
+
+// Subtract two unsigned 64bit integers.
+// Returns 0 instead of underflowing.
+func SatSub64(x, y uint64) uint64 {
+	if y > x {
+		return 0
+	}
+	return x - y
+}
+
+// Multiply two unsigned 64bit integers.
+// Returns [math.MaxUint64] instead of overflowing.
+func SatMul64(x, y uint64) uint64 {
+	hi, lo := bits.Mul64(x, y)
+	if hi > 0 {
+		return math.MaxUint64
+	}
+	return lo
+}
+
+// Add two integers of the same type.
+// Returns the maximum or minimum value of T instead of overflowing or underflowing.
+func SatAdd[T constraints.Integer](x, y T) T {
+	result, err := Add(x, y)
+	return saturate(result, err)
+}
+
+// Subtract y from x where both are integers of the same type.
+// Returns the maximum or minimum value of T instead of overflowing or underflowing.
+func SatSub[T constraints.Integer](x, y T) T {
+	result, err := Sub(x, y)
+	return saturate(result, err)
+}
+
+// Multiply two integers of the same type.
+// Returns the maximum or minimum value of T instead of overflowing or underflowing.
+func SatMul[T constraints.Integer](x, y T) T {
+	result, err := Mul(x, y)
+	return saturate(result, err)
+}
+
+// Return the maximum value of T on overflow or the minimum value of T on underflow.
+func saturate[T constraints.Integer](result T, err error) T {
+	switch err {
+	case ErrIntegerOverflow:
+		return maxOf[T]()
+	case ErrIntegerUnderflow:
+		return minOf[T]()
+	}
+	return result
+}
+
+// Return the maximum value that can be represented by T.
+func maxOf[T constraints.Integer]() T {
+	ones := ^T(0)
+	if ones > 0 {
+		// unsigned
+		return ones
+	}
+	size := unsafe.Sizeof(ones) * 8
+	return T(uint64(1)<<(size-1) - 1)
+}
+
+// Return the minimum value that can be represented by T.
+func minOf[T constraints.Integer]() T {
+	if ^T(0) > 0 {
+		// unsigned
+		return 0
+	}
+	return -maxOf[T]() - 1
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSatAdd64(t *testing.T) {
+	assert.Equal(t, uint64(84), safe.SatAdd64(42, 42))
+	assert.Equal(t, uint64(math.MaxUint64), safe.SatAdd64(math.MaxUint64, 1))
+}
+
+func TestSatSub64(t *testing.T) {
+	assert.Equal(t, uint64(0), safe.SatSub64(42, 42))
+	assert.Equal(t, uint64(0), safe.SatSub64(42, 43))
+	assert.Equal(t, uint64(1), safe.SatSub64(43, 42))
+}
+
+func TestSatMul64(t *testing.T) {
+	assert.Equal(t, uint64(42), safe.SatMul64(6, 7))
+	assert.Equal(t, uint64(math.MaxUint64), safe.SatMul64(1<<32, 1<<32))
+}
+
+func TestSatGeneric(t *testing.T) {
+	assert.Equal(t, uint8(255), safe.SatAdd[uint8](200, 100))
+	assert.Equal(t, uint8(0), safe.SatSub[uint8](100, 200))
+	assert.Equal(t, uint8(255), safe.SatMul[uint8](16, 16))
+
+	assert.Equal(t, int8(127), safe.SatAdd[int8](100, 100))
+	assert.Equal(t, int8(-128), safe.SatAdd[int8](-100, -100))
+	assert.Equal(t, int8(-128), safe.SatSub[int8](-100, 100))
+	assert.Equal(t, int8(127), safe.SatSub[int8](100, -100))
+	assert.Equal(t, int8(127), safe.SatMul[int8](math.MinInt8, -1))
+	assert.Equal(t, int8(-128), safe.SatMul[int8](64, -3))
+
+	assert.Equal(t, int64(math.MaxInt64), safe.SatAdd[int64](math.MaxInt64, 1))
+	assert.Equal(t, int64(math.MinInt64), safe.SatSub[int64](math.MinInt64, 1))
+	assert.Equal(t, int32(math.MaxInt32), safe.SatMul[int32](math.MaxInt32, 2))
+	assert.Equal(t, uint(math.MaxUint), safe.SatAdd[uint](math.MaxUint, 1))
+	assert.Equal(t, int(math.MinInt), safe.SatSub[int](math.MinInt, 1))
+
+	assert.Equal(t, int16(-42), safe.SatAdd[int16](-50, 8))
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe
+
+import (
+	"math"
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Add two signed 32bit integers.
+// Returns [ErrIntegerOverflow] if the result is bigger than [math.MaxInt32].
+// Returns [ErrIntegerUnderflow] if the result is smaller than [math.MinInt32].
+func AddInt32(x, y int32) (int32, error) {
+	return Add(x, y)
+}
+
+// Add two signed 64bit integers.
+// Returns [ErrIntegerOverflow] if the result is bigger than [math.MaxInt64].
+// Returns [ErrIntegerUnderflow] if the result is smaller than [math.MinInt64].
+func AddInt64(x, y int64) (int64, error) {
+	return Add(x, y)
+}
+
+// Subtract two signed 32bit integers.
+// Returns [ErrIntegerOverflow] if the result is bigger than [math.MaxInt32].
+// Returns [ErrIntegerUnderflow] if the result is smaller than [math.MinInt32].
+func SubInt32(x, y int32) (int32, error) {
+	return Sub(x, y)
+}
+
+// Subtract two signed 64bit integers.
+// Returns [ErrIntegerOverflow] if the result is bigger than [math.MaxInt64].
+// Returns [ErrIntegerUnderflow] if the result is smaller than [math.MinInt64].
+func SubInt64(x, y int64) (int64, error) {
+	return Sub(x, y)
+}
+
+// Multiply two signed 32bit integers.
+// Returns [ErrIntegerOverflow] if the result is bigger than [math.MaxInt32].
+// Returns [ErrIntegerUnderflow] if the result is smaller than [math.MinInt32].
+func MulInt32(x, y int32) (int32, error) {
+	result := int64(x) * int64(y)
+	if result > math.MaxInt32 {
+		return 0, ErrIntegerOverflow
+	} else if result < math.MinInt32 {
+		return 0, ErrIntegerUnderflow
+	}
+	return int32(result), nil
+}
+
+// Multiply two signed 64bit integers.
+// Returns [ErrIntegerOverflow] if the result is bigger than [math.MaxInt64].
+// Returns [ErrIntegerUnderflow] if the result is smaller than [math.MinInt64].
+func MulInt64(x, y int64) (int64, error) {
+	negative := (x < 0) != (y < 0)
+	hi, lo := bits.Mul64(absUint64(x), absUint64(y))
+
+	if negative {
+		// The magnitude of MinInt64 is one more than MaxInt64
+		if hi > 0 || lo > 1<<63 {
+			return 0, ErrIntegerUnderflow
+		}
+		return int64(-lo), nil
+	}
+
+	if hi > 0 || lo > math.MaxInt64 {
+		return 0, ErrIntegerOverflow
+	}
+	return int64(lo), nil
+}
+
+// Return the magnitude of x as an unsigned 64bit integer. Also valid for math.MinInt64.
+func absUint64(x int64) uint64 {
+	if x < 0 {
+		return uint64(-(x + 1)) + 1
+	}
+	return uint64(x)
+}
+
+// Return the absolute value of a signed 32bit integer.
+// Returns [ErrIntegerOverflow] if x is [math.MinInt32] since the result can not be represented.
+func Abs32(x int32) (int32, error) {
+	return Abs(x)
+}
+
+// Return the absolute value of a signed 64bit integer.
+// Returns [ErrIntegerOverflow] if x is [math.MinInt64] since the result can not be represented.
+func Abs64(x int64) (int64, error) {
+	return Abs(x)
+}
+
+// Return the absolute value of a signed integer.
+// Returns [ErrIntegerOverflow] if x is the minimum value of T since the result can not be represented.
+func Abs[T constraints.Signed](x T) (T, error) {
+	if x >= 0 {
+		return x, nil
+	}
+	if -x < 0 {
+		// -MinInt == MinInt
+		return 0, ErrIntegerOverflow
+	}
+	return -x, nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package safe_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath/safe"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddInt32(t *testing.T) {
+	v, err := safe.AddInt32(-42, 84)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(42), v)
+
+	v, err = safe.AddInt32(math.MaxInt32, 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, int32(0), v)
+
+	_, err = safe.AddInt32(math.MinInt32, -1)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestAddInt64(t *testing.T) {
+	v, err := safe.AddInt64(math.MinInt64, math.MaxInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), v)
+
+	v, err = safe.AddInt64(math.MaxInt64, 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, int64(0), v)
+
+	_, err = safe.AddInt64(math.MinInt64, math.MinInt64)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestSubInt32(t *testing.T) {
+	v, err := safe.SubInt32(-42, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-84), v)
+
+	_, err = safe.SubInt32(0, math.MinInt32)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.SubInt32(math.MinInt32, 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestSubInt64(t *testing.T) {
+	v, err := safe.SubInt64(-1, math.MinInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), v)
+
+	v, err = safe.SubInt64(0, math.MinInt64)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, int64(0), v)
+
+	_, err = safe.SubInt64(math.MinInt64, 1)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestMulInt32(t *testing.T) {
+	v, err := safe.MulInt32(-6, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-42), v)
+
+	v, err = safe.MulInt32(math.MinInt32, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(math.MinInt32), v)
+
+	v, err = safe.MulInt32(math.MaxInt32, 2)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, int32(0), v)
+
+	_, err = safe.MulInt32(math.MinInt32, -1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.MulInt32(math.MaxInt32, -2)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestMulInt64(t *testing.T) {
+	v, err := safe.MulInt64(-6, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-42), v)
+
+	v, err = safe.MulInt64(-6, -7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+
+	v, err = safe.MulInt64(0, math.MinInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), v)
+
+	v, err = safe.MulInt64(math.MinInt64, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MinInt64), v)
+
+	v, err = safe.MulInt64(math.MinInt64/2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MinInt64), v)
+
+	v, err = safe.MulInt64(math.MaxInt64, 2)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, int64(0), v)
+
+	_, err = safe.MulInt64(math.MinInt64, -1)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+
+	_, err = safe.MulInt64(math.MinInt64, 2)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+
+	_, err = safe.MulInt64(math.MaxInt64, math.MinInt64)
+	assert.ErrorIs(t, err, safe.ErrIntegerUnderflow)
+}
+
+func TestAbs32(t *testing.T) {
+	v, err := safe.Abs32(-42)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(42), v)
+
+	v, err = safe.Abs32(math.MaxInt32)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(math.MaxInt32), v)
+
+	v, err = safe.Abs32(math.MinInt32)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+	assert.Equal(t, int32(0), v)
+}
+
+func TestAbs64(t *testing.T) {
+	v, err := safe.Abs64(-42)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+
+	v, err = safe.Abs64(math.MinInt64 + 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), v)
+
+	_, err = safe.Abs64(math.MinInt64)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
+
+func TestAbs(t *testing.T) {
+	v, err := safe.Abs[int8](-127)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(127), v)
+
+	v, err = safe.Abs[int8](0)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(0), v)
+
+	_, err = safe.Abs[int8](math.MinInt8)
+	assert.ErrorIs(t, err, safe.ErrIntegerOverflow)
+}
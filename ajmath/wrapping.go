@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath
+
+import "golang.org/x/exp/constraints"
+
+// Add two integers using modular arithmetic.
+// The result deliberately wraps around on overflow, use the safe package when that is a bug.
+func WrapAdd[T constraints.Integer](x, y T) T {
+	return x + y
+}
+
+// Subtract y from x using modular arithmetic.
+// The result deliberately wraps around on underflow, use the safe package when that is a bug.
+func WrapSub[T constraints.Integer](x, y T) T {
+	return x - y
+}
+
+// Multiply two integers using modular arithmetic.
+// The result deliberately wraps around on overflow, use the safe package when that is a bug.
+func WrapMul[T constraints.Integer](x, y T) T {
+	return x * y
+}
+
+// Wrapping is an integer on which all arithmetic deliberately wraps around.
+// For example sequence numbers in a protocol. It documents that the wraparound is intended.
+type Wrapping[T constraints.Integer] struct {
+	value T
+}
+
+// Create a new Wrapping integer with the initial value.
+func NewWrapping[T constraints.Integer](value T) Wrapping[T] {
+	return Wrapping[T]{value: value}
+}
+
+// Return the value.
+func (w Wrapping[T]) Value() T {
+	return w.value
+}
+
+// Return w + n, wrapping around on overflow.
+func (w Wrapping[T]) Add(n T) Wrapping[T] {
+	return Wrapping[T]{value: WrapAdd(w.value, n)}
+}
+
+// Return w - n, wrapping around on underflow.
+func (w Wrapping[T]) Sub(n T) Wrapping[T] {
+	return Wrapping[T]{value: WrapSub(w.value, n)}
+}
+
+// Return w * n, wrapping around on overflow.
+func (w Wrapping[T]) Mul(n T) Wrapping[T] {
+	return Wrapping[T]{value: WrapMul(w.value, n)}
+}
+
+// Return w + 1, wrapping around on overflow.
+func (w Wrapping[T]) Next() Wrapping[T] {
+	return w.Add(1)
+}
+
+// Return the distance from other to w, i.e. the number of times Next has to be called on other to reach w.
+func (w Wrapping[T]) Distance(other Wrapping[T]) T {
+	return WrapSub(w.value, other.value)
+}
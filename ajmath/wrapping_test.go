@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ajmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajmath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapFuncs(t *testing.T) {
+	assert.Equal(t, uint8(4), ajmath.WrapAdd[uint8](250, 10))
+	assert.Equal(t, uint8(251), ajmath.WrapSub[uint8](5, 10))
+	assert.Equal(t, uint8(0), ajmath.WrapMul[uint8](16, 16))
+	assert.Equal(t, int8(math.MinInt8), ajmath.WrapAdd[int8](math.MaxInt8, 1))
+	assert.Equal(t, int8(math.MaxInt8), ajmath.WrapSub[int8](math.MinInt8, 1))
+}
+
+func TestWrapping(t *testing.T) {
+	seq := ajmath.NewWrapping[uint16](math.MaxUint16 - 1)
+	assert.Equal(t, uint16(math.MaxUint16-1), seq.Value())
+
+	next := seq.Next().Next().Next()
+	assert.Equal(t, uint16(1), next.Value())
+	assert.Equal(t, uint16(3), next.Distance(seq))
+
+	assert.Equal(t, uint16(math.MaxUint16), next.Sub(2).Value())
+	assert.Equal(t, uint16(11), next.Add(10).Value())
+	assert.Equal(t, uint16(0xFFFE), seq.Mul(1).Value())
+	assert.Equal(t, uint16(0xFFFC), seq.Mul(2).Value())
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"time"
+)
+
+// Consume from the 'in' channel and produce the values grouped into batches.
+// A batch is produced once it contains maxSize values or maxWait has elapsed since the first value
+// was added to it, whichever happens first. A maxWait of 0 means batches are only produced by size.
+// Any partial batch is produced when the 'in' channel is closed, after which the returned channel is closed.
+// When the context is canceled the partial batch is discarded and the returned channel is closed.
+func Batch[T any](ctx context.Context, in <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	maxSize = max(maxSize, 1)
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+
+		var batch []T
+		var timer *time.Timer
+		var timeout <-chan time.Time
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timeout = nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- batch:
+				batch = nil
+				return true
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			case data, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+
+				if batch == nil {
+					batch = make([]T, 0, maxSize)
+					if maxWait > 0 {
+						if timer == nil {
+							timer = time.NewTimer(maxWait)
+						} else {
+							timer.Reset(maxWait)
+						}
+						timeout = timer.C
+					}
+				}
+				batch = append(batch, data)
+
+				if len(batch) >= maxSize && !flush() {
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchBySize(t *testing.T) {
+	producer := make(chan int)
+	go func() {
+		for i := 0; i < 10; i++ {
+			producer <- i
+		}
+		close(producer)
+	}()
+
+	var batches [][]int
+	for b := range concurrency.Batch(context.Background(), producer, 4, 0) {
+		batches = append(batches, b)
+	}
+
+	assert.Equal(t, [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9}}, batches)
+}
+
+func TestBatchByTime(t *testing.T) {
+	producer := make(chan int)
+	out := concurrency.Batch(context.Background(), producer, 100, 20*time.Millisecond)
+
+	producer <- 1
+	producer <- 2
+	start := time.Now()
+	b := <-out
+	assert.Equal(t, []int{1, 2}, b)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	// The timer restarts with the next batch
+	producer <- 3
+	assert.Equal(t, []int{3}, <-out)
+
+	close(producer)
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestBatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	producer := make(chan int)
+	out := concurrency.Batch(ctx, producer, 10, 0)
+
+	producer <- 1
+	cancel()
+
+	select {
+	case b, ok := <-out:
+		require.False(t, ok, "unexpected batch %v", b)
+	case <-time.After(time.Second):
+		t.Fatal("expected the batch channel to be closed")
+	}
+}
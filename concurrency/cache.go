@@ -0,0 +1,248 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Get was called for a missing entry on a Cache without a loader.
+var ErrCacheMiss = errors.New("concurrency: cache entry not found")
+
+// Cache is a concurrency safe cache where each entry expires after a time to live and the least recently
+// used entries are evicted once a maximum number of entries is reached.
+// Missing entries are populated by a loader function, where concurrent requests for the same key share
+// a single call to the loader.
+type Cache[K comparable, V any] struct {
+	ttl        time.Duration
+	maxEntries int
+	loader     func(ctx context.Context, key K) (V, error)
+
+	mu       sync.Mutex
+	lru      list.List // front is the most recently used
+	entries  map[K]*list.Element
+	inflight map[K]*cacheCall[V]
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero when the entry never expires
+}
+
+type cacheCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Create a new Cache where entries expire after ttl and at most maxEntries are kept.
+// A ttl of 0 means entries never expire and a maxEntries of 0 means there is no limit.
+// The loader is called by Get to populate missing or expired entries and can be nil if only Set is used.
+func NewCache[K comparable, V any](ttl time.Duration, maxEntries int,
+	loader func(ctx context.Context, key K) (V, error)) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		loader:     loader,
+		entries:    make(map[K]*list.Element),
+		inflight:   make(map[K]*cacheCall[V]),
+	}
+}
+
+// Get returns the value for the key, calling the loader if the entry is missing or expired.
+// Concurrent calls for the same key wait for the same loader call, which is passed the values of the context
+// of the caller that started it but not its cancellation, so that one caller giving up does not fail the others.
+// Errors returned by the loader (and panics which are converted to a [PanicError]) are not cached.
+// Returns the cause of the context cancellation if it is done while waiting, the loader keeps running and
+// its value is still cached unless the key was Set, Deleted or Purged in the meantime.
+func (c *Cache[K, V]) Get(ctx context.Context, key K) (V, error) {
+	c.mu.Lock()
+	if v, ok := c.lookup(key); ok {
+		c.mu.Unlock()
+		return v, nil
+	}
+
+	call, ok := c.inflight[key]
+	if !ok {
+		if c.loader == nil {
+			c.mu.Unlock()
+			var zero V
+			return zero, ErrCacheMiss
+		}
+
+		call = &cacheCall[V]{done: make(chan struct{})}
+		c.inflight[key] = call
+		go c.load(context.WithoutCancel(ctx), key, call)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		var zero V
+		return zero, context.Cause(ctx)
+	case <-call.done:
+		return call.value, call.err
+	}
+}
+
+// Peek returns the value for the key without calling the loader or updating how recently it was used.
+// Returns false if the entry is missing or expired.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*cacheEntry[K, V])
+	if c.expired(entry, time.Now()) {
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// Set the value for the key, replacing any existing entry.
+// A loader call in progress for the key will not replace the value.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inflight, key)
+	c.set(key, value)
+}
+
+// Delete the entry for the key.
+// A loader call in progress for the key will not store its value.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inflight, key)
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Len returns the number of entries, including those that have expired but not yet been removed.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Purge removes all the entries.
+// Loader calls in progress will not store their values.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	clear(c.entries)
+	clear(c.inflight)
+}
+
+func (c *Cache[K, V]) load(ctx context.Context, key K, call *cacheCall[V]) {
+	call.err = callRecover(func() error {
+		var err error
+		call.value, err = c.loader(ctx, key)
+		return err
+	})
+
+	c.mu.Lock()
+	// The call is no longer in flight when it was invalidated by Set, Delete or Purge
+	if c.inflight[key] == call {
+		delete(c.inflight, key)
+		if call.err == nil {
+			c.set(key, call.value)
+		}
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// Return the value if the entry exists and has not expired. Must be called with the lock held.
+func (c *Cache[K, V]) lookup(key K) (V, bool) {
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	entry := elem.Value.(*cacheEntry[K, V])
+	if c.expired(entry, time.Now()) {
+		c.remove(elem)
+		return zero, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Must be called with the lock held.
+func (c *Cache[K, V]) set(key K, value V) {
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = time.Now().Add(c.ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry[K, V])
+		entry.value = value
+		entry.expires = expires
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry[K, V]{key: key, value: value, expires: expires})
+	c.evict()
+}
+
+// Remove entries until the maximum number of entries is not exceeded, preferring the expired entries
+// before the least recently used. Must be called with the lock held.
+func (c *Cache[K, V]) evict() {
+	if c.maxEntries <= 0 || len(c.entries) <= c.maxEntries {
+		return
+	}
+
+	now := time.Now()
+	for elem := c.lru.Back(); elem != nil && len(c.entries) > c.maxEntries; {
+		prev := elem.Prev()
+		if c.expired(elem.Value.(*cacheEntry[K, V]), now) {
+			c.remove(elem)
+		}
+		elem = prev
+	}
+
+	for len(c.entries) > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache[K, V]) expired(entry *cacheEntry[K, V], now time.Time) bool {
+	return !entry.expires.IsZero() && !now.Before(entry.expires)
+}
+
+func (c *Cache[K, V]) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry[K, V]).key)
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := concurrency.NewCache(0, 0, func(ctx context.Context, key string) (int, error) {
+		calls.Add(1)
+		<-release
+		return len(key), nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.Get(context.Background(), "hello")
+			assert.NoError(t, err)
+			assert.Equal(t, 5, v)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Cached
+	v, err := c.Get(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.Equal(t, 5, v)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCacheTTL(t *testing.T) {
+	var calls atomic.Int32
+	c := concurrency.NewCache(20*time.Millisecond, 0, func(ctx context.Context, key int) (int, error) {
+		return int(calls.Add(1)), nil
+	})
+	ctx := context.Background()
+
+	v, err := c.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, _ = c.Get(ctx, 1)
+	assert.Equal(t, 1, v)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok := c.Peek(1)
+	assert.False(t, ok)
+	v, _ = c.Get(ctx, 1)
+	assert.Equal(t, 2, v)
+}
+
+func TestCacheMaxEntries(t *testing.T) {
+	c := concurrency.NewCache[string, int](0, 3, nil)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	// Use "a" so that "b" is the least recently used
+	v, err := c.Get(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	c.Set("d", 4)
+	assert.Equal(t, 3, c.Len())
+	_, ok := c.Peek("b")
+	assert.False(t, ok)
+
+	_, err = c.Get(context.Background(), "b")
+	assert.ErrorIs(t, err, concurrency.ErrCacheMiss)
+
+	for _, key := range []string{"a", "c", "d"} {
+		_, ok := c.Peek(key)
+		assert.True(t, ok, key)
+	}
+
+	c.Delete("a")
+	assert.Equal(t, 2, c.Len())
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCacheErrorsNotCached(t *testing.T) {
+	errBad := errors.New("bad")
+	var calls atomic.Int32
+	c := concurrency.NewCache(0, 0, func(ctx context.Context, key int) (string, error) {
+		switch calls.Add(1) {
+		case 1:
+			return "", errBad
+		case 2:
+			panic("oops")
+		}
+		return fmt.Sprint(key), nil
+	})
+	ctx := context.Background()
+
+	_, err := c.Get(ctx, 7)
+	assert.ErrorIs(t, err, errBad)
+
+	_, err = c.Get(ctx, 7)
+	var panicErr *concurrency.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+
+	v, err := c.Get(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "7", v)
+}
+
+func TestCacheGetCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	c := concurrency.NewCache(0, 0, func(ctx context.Context, key int) (int, error) {
+		<-release
+		return key, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.Get(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCacheFirstCallerCanceled(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	c := concurrency.NewCache(0, 0, func(ctx context.Context, key int) (int, error) {
+		calls.Add(1)
+		close(started)
+		select {
+		case <-ctx.Done():
+			return 0, context.Cause(ctx)
+		case <-release:
+			return key * 10, nil
+		}
+	})
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.Get(ctx1, 4)
+		firstErr <- err
+	}()
+	<-started
+
+	secondValue := make(chan int, 1)
+	secondErr := make(chan error, 1)
+	go func() {
+		v, err := c.Get(context.Background(), 4)
+		secondValue <- v
+		secondErr <- err
+	}()
+
+	cancel1()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-secondErr)
+	assert.Equal(t, 40, <-secondValue)
+	assert.Equal(t, int32(1), calls.Load())
+
+	v, ok := c.Peek(4)
+	assert.True(t, ok)
+	assert.Equal(t, 40, v)
+}
+
+func TestCacheInvalidatedWhileLoading(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	c := concurrency.NewCache(0, 0, func(ctx context.Context, key int) (int, error) {
+		started <- struct{}{}
+		<-release
+		return key * 10, nil
+	})
+
+	tests := []struct {
+		name       string
+		invalidate func()
+		expected   int
+		exists     bool
+	}{
+		{"delete", func() { c.Delete(4) }, 0, false},
+		{"set", func() { c.Set(4, 1) }, 1, true},
+		{"purge", c.Purge, 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Delete(4)
+			loaded := make(chan int, 1)
+			go func() {
+				v, err := c.Get(context.Background(), 4)
+				assert.NoError(t, err)
+				loaded <- v
+			}()
+			<-started
+
+			tc.invalidate()
+			release <- struct{}{}
+
+			// The waiting caller still receives the loaded value but it is not stored
+			assert.Equal(t, 40, <-loaded)
+			v, ok := c.Peek(4)
+			assert.Equal(t, tc.exists, ok)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+)
+
+// Send the value to the channel, blocking until it is received or the context is done.
+// Returns the cause of the context cancellation if the value was not sent.
+func Send[T any](ctx context.Context, ch chan<- T, v T) error {
+	// Don't send when the context is already done, select would pick randomly if the channel is ready
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case ch <- v:
+		return nil
+	}
+}
+
+// Recv receives a value from the channel, blocking until one is available or the context is done.
+// The returned bool is false when the channel has been closed, in which case the zero value is returned.
+// Returns the cause of the context cancellation if no value was received.
+func Recv[T any](ctx context.Context, ch <-chan T) (T, bool, error) {
+	var zero T
+	if ctx.Err() != nil {
+		return zero, false, context.Cause(ctx)
+	}
+
+	select {
+	case <-ctx.Done():
+		return zero, false, context.Cause(ctx)
+	case v, ok := <-ch:
+		return v, ok, nil
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	ch := make(chan int, 1)
+	require.NoError(t, concurrency.Send(context.Background(), ch, 42))
+	assert.Equal(t, 42, <-ch)
+
+	errStop := errors.New("stop")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errStop)
+
+	// Even though the channel has room
+	assert.ErrorIs(t, concurrency.Send(ctx, ch, 1), errStop)
+	assert.Empty(t, ch)
+
+	// Blocked
+	ctx, cancel = context.WithCancelCause(context.Background())
+	go cancel(errStop)
+	assert.ErrorIs(t, concurrency.Send(ctx, make(chan int), 1), errStop)
+}
+
+func TestRecv(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 42
+
+	v, ok, err := concurrency.Recv(context.Background(), ch)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 42, v)
+
+	close(ch)
+	v, ok, err = concurrency.Recv(context.Background(), ch)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, v)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+	_, ok, err = concurrency.Recv(ctx, make(chan int))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, ok)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/andrejacobs/go-aj/ajio"
+)
+
+// DefaultChunkSize is the size of the chunks produced by [ReadChunks] when none is specified.
+const DefaultChunkSize = 32 * 1024
+
+// Chunk is a piece of data read from an io.Reader by [ReadChunks].
+type Chunk struct {
+	Data   []byte // data read, which is borrowed from ajio.DefaultBufferPool
+	Offset int64  // offset of the data from the start of the reader
+	Err    error  // error that stopped the reading, in which case this is the last chunk and Data is empty
+}
+
+// Release returns the data to the buffer pool. The data must not be used afterwards.
+func (c *Chunk) Release() {
+	if c.Data != nil {
+		ajio.DefaultBufferPool.Put(c.Data)
+		c.Data = nil
+	}
+}
+
+// ReadChunks reads from r in a new goroutine and produces the data in chunks of chunkSize bytes,
+// except for the last chunk which may be smaller. The data buffers are borrowed from ajio.DefaultBufferPool
+// and the consumer should call [Chunk.Release] once done with a chunk.
+//
+// The returned channel is closed once the end of the reader is reached or the context is canceled.
+// When reading fails a final chunk is produced with the Err set.
+// If chunkSize is less than 1 then [DefaultChunkSize] is used.
+func ReadChunks(ctx context.Context, r io.Reader, chunkSize int) <-chan Chunk {
+	if chunkSize < 1 {
+		chunkSize = DefaultChunkSize
+	}
+	out := make(chan Chunk)
+
+	go func() {
+		defer close(out)
+
+		var offset int64
+		for ctx.Err() == nil {
+			chunk := Chunk{Data: ajio.DefaultBufferPool.Get(chunkSize), Offset: offset}
+			n, err := io.ReadFull(r, chunk.Data)
+			chunk.Data = chunk.Data[:n]
+			offset += int64(n)
+
+			if n == 0 {
+				chunk.Release()
+			} else if !sendChunk(ctx, out, chunk) {
+				return
+			}
+
+			if err != nil {
+				if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+					sendChunk(ctx, out, Chunk{Offset: offset, Err: err})
+				}
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Send the chunk or release it if the context is done first.
+func sendChunk(ctx context.Context, out chan<- Chunk, chunk Chunk) bool {
+	if err := Send(ctx, out, chunk); err != nil {
+		chunk.Release()
+		return false
+	}
+	return true
+}
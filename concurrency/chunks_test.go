@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadChunks(t *testing.T) {
+	data := make([]byte, 10*1024+17)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	// One byte at a time to check chunks are filled completely
+	r := iotest.OneByteReader(bytes.NewReader(data))
+
+	var result []byte
+	var sizes []int
+	expectedOffset := int64(0)
+	for chunk := range concurrency.ReadChunks(context.Background(), r, 1024) {
+		require.NoError(t, chunk.Err)
+		assert.Equal(t, expectedOffset, chunk.Offset)
+		expectedOffset += int64(len(chunk.Data))
+		sizes = append(sizes, len(chunk.Data))
+		result = append(result, chunk.Data...)
+		chunk.Release()
+		assert.Nil(t, chunk.Data)
+	}
+
+	assert.Equal(t, data, result)
+	require.Len(t, sizes, 11)
+	assert.Equal(t, 1024, sizes[0])
+	assert.Equal(t, 17, sizes[10])
+}
+
+func TestReadChunksExactMultiple(t *testing.T) {
+	chunks := drain(concurrency.ReadChunks(context.Background(), bytes.NewReader(make([]byte, 200)), 100))
+	assert.Len(t, chunks, 2)
+
+	chunks = drain(concurrency.ReadChunks(context.Background(), bytes.NewReader(nil), 0))
+	assert.Empty(t, chunks)
+}
+
+func TestReadChunksError(t *testing.T) {
+	errBad := errors.New("bad")
+	r := io.MultiReader(bytes.NewReader([]byte("hello")), iotest.ErrReader(errBad))
+
+	chunks := drain(concurrency.ReadChunks(context.Background(), r, 3))
+	require.Len(t, chunks, 3)
+	assert.Equal(t, "hel", string(chunks[0].Data))
+	assert.Equal(t, "lo", string(chunks[1].Data))
+	assert.ErrorIs(t, chunks[2].Err, errBad)
+	assert.Equal(t, int64(5), chunks[2].Offset)
+	assert.Empty(t, chunks[2].Data)
+}
+
+func TestReadChunksCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := concurrency.ReadChunks(ctx, rand.Reader, 64)
+	<-out
+	cancel()
+
+	for range out {
+	}
+}
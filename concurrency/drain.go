@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+)
+
+// Drain consumes and discards the values from the channel until it is closed or the context is done,
+// so that the goroutines producing to the channel are not left blocked when a pipeline is abandoned early.
+// Returns the number of values discarded and the cause of the context cancellation if it was done
+// before the channel was closed.
+func Drain[T any](ctx context.Context, ch <-chan T) (int, error) {
+	count := 0
+	for {
+		_, ok, err := Recv(ctx, ch)
+		if err != nil {
+			return count, err
+		}
+		if !ok {
+			return count, nil
+		}
+		count++
+	}
+}
+
+// DrainAndClose aborts a pipeline by closing the feed channel that is owned by the caller and is the input to
+// the pipeline, and then draining the output of the pipeline until it is closed or the context is done.
+// See [Drain] for the results.
+func DrainAndClose[T any, V any](ctx context.Context, feed chan<- T, out <-chan V) (int, error) {
+	close(feed)
+	return Drain(ctx, out)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrain(t *testing.T) {
+	ch := make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	n, err := concurrency.Drain(context.Background(), ch)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	n, err = concurrency.Drain(ctx, make(chan int))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, n)
+}
+
+func TestDrainAndClose(t *testing.T) {
+	ctx := context.Background()
+	feed := make(chan string)
+	out, errs := concurrency.Stage(ctx, feed, 2, strconv.Atoi)
+	go func() {
+		for range errs {
+		}
+	}()
+
+	feed <- "1"
+	feed <- "2"
+	assert.NotZero(t, <-out)
+
+	// Abandon the pipeline without leaving the stage's workers blocked
+	n, err := concurrency.DrainAndClose(ctx, feed, out)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
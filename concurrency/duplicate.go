@@ -24,48 +24,156 @@ import (
 )
 
 // Consume from the 'in' channel and produce the same value to all of the output channels.
-func Fanout[T any](ctx context.Context, in <-chan T, outs ...chan T) {
-loop:
+// See [TransformedFanoutWithPolicy] for how the returned channel is used to signal completion.
+func Fanout[T any](ctx context.Context, in <-chan T, outs ...chan T) <-chan error {
+	return FanoutWithPolicy(ctx, in, blockingConsumers(outs)...)
+}
+
+// Consume from the 'in' channel and produce the same value to all of the consumers.
+// Each consumer's policy determines whether a slow consumer can stall the others.
+// See [TransformedFanoutWithPolicy] for how the returned channel is used to signal completion.
+func FanoutWithPolicy[T any](ctx context.Context, in <-chan T, consumers ...FanoutConsumer[T]) <-chan error {
+	return TransformedFanoutWithPolicy(ctx, func(in T) T { return in }, in, consumers...)
+}
+
+// Consume from the 'in' channel and produce the a transformed value to the output channels.
+// Meaning consume T and produce V.
+// See [TransformedFanoutWithPolicy] for how the returned channel is used to signal completion.
+func TransformedFanout[T any, V any](ctx context.Context,
+	transformer func(in T) V,
+	in <-chan T, outs ...chan V) <-chan error {
+	return TransformedFanoutWithPolicy(ctx, transformer, in, blockingConsumers(outs)...)
+}
+
+// Consume from the 'in' channel and produce the a transformed value to the consumers.
+// Each consumer's policy determines whether a slow consumer can stall the others.
+//
+// The fanout runs in its own goroutine until the 'in' channel is closed or the context is canceled.
+// All the consumer channels are closed when it stops, even when blocked on a slow consumer,
+// after which the returned channel receives the reason for stopping and is closed.
+// The reason is nil when the 'in' channel was closed, otherwise the cause of the context cancellation.
+func TransformedFanoutWithPolicy[T any, V any](ctx context.Context,
+	transformer func(in T) V,
+	in <-chan T, consumers ...FanoutConsumer[V]) <-chan error {
+	done := make(chan error, 1)
+
+	go func() {
+		queued, wg := decouple(ctx, consumers)
+		err := fanout(ctx, transformer, in, queued)
+		for _, c := range queued {
+			close(c.Out)
+		}
+		wg.Wait()
+		done <- err
+		close(done)
+	}()
+
+	return done
+}
+
+func fanout[T any, V any](ctx context.Context,
+	transformer func(in T) V,
+	in <-chan T, consumers []FanoutConsumer[V]) error {
 	for {
 		select {
 		case <-ctx.Done():
-			break loop
+			return context.Cause(ctx)
 		case data, ok := <-in:
 			if !ok {
-				break loop
+				return nil
 			}
-			for _, out := range outs {
-				out <- data
+			for _, c := range consumers {
+				if !deliver(ctx, c.Out, transformer(data), c.Policy) && ctx.Err() != nil {
+					return context.Cause(ctx)
+				}
 			}
 		}
 	}
+}
 
-	for _, out := range outs {
-		close(out)
-	}
+// Consume from the 'in' channel and produce the transformed value to the consumers, where the transformer
+// can fail. The transformer is called once per value and the same result is delivered to all consumers.
+// Each consumer's policy determines whether a slow consumer can stall the others.
+//
+// When the transformer returns an error the value is not delivered to the consumers and instead the error
+// is produced to the errs channel (blocking until it is received or the context is canceled).
+// If errs is nil then the values that failed to transform are dropped silently.
+// The errs channel is closed along with the consumer channels. See [TransformedFanoutWithPolicy] for how
+// the returned channel is used to signal completion.
+func TransformedFanoutWithErrors[T any, V any](ctx context.Context,
+	transformer func(in T) (V, error),
+	in <-chan T, errs chan error, consumers ...FanoutConsumer[V]) <-chan error {
+	done := make(chan error, 1)
+
+	go func() {
+		queued, wg := decouple(ctx, consumers)
+		err := fanoutWithErrors(ctx, transformer, in, errs, queued)
+		for _, c := range queued {
+			close(c.Out)
+		}
+		wg.Wait()
+		if errs != nil {
+			close(errs)
+		}
+		done <- err
+		close(done)
+	}()
+
+	return done
 }
 
-// Consume from the 'in' channel and produce the a transformed value to the output channels.
-// Meaning consume T and produce V.
-func TransformedFanout[T any, V any](ctx context.Context,
-	transformer func(in T) V,
-	in <-chan T, outs ...chan V) {
-loop:
+func fanoutWithErrors[T any, V any](ctx context.Context,
+	transformer func(in T) (V, error),
+	in <-chan T, errs chan error, consumers []FanoutConsumer[V]) error {
 	for {
 		select {
 		case <-ctx.Done():
-			break loop
+			return context.Cause(ctx)
 		case data, ok := <-in:
 			if !ok {
-				break loop
+				return nil
 			}
-			for _, out := range outs {
-				out <- transformer(data)
+
+			v, err := transformer(data)
+			if err != nil {
+				if errs != nil {
+					select {
+					case <-ctx.Done():
+						return context.Cause(ctx)
+					case errs <- err:
+					}
+				}
+				continue
+			}
+
+			for _, c := range consumers {
+				if !deliver(ctx, c.Out, v, c.Policy) && ctx.Err() != nil {
+					return context.Cause(ctx)
+				}
 			}
 		}
 	}
+}
 
-	for _, out := range outs {
-		close(out)
+func blockingConsumers[T any](outs []chan T) []FanoutConsumer[T] {
+	consumers := make([]FanoutConsumer[T], len(outs))
+	for i, out := range outs {
+		consumers[i] = FanoutConsumer[T]{Out: out, Policy: Block}
 	}
+	return consumers
+}
+
+// Tee creates n output channels and produces every value consumed from the 'in' channel to all of them.
+// The output channels are closed once the 'in' channel is closed or the context is canceled.
+// Every output channel must be consumed, since a slow consumer blocks the others. See [Fanout].
+func Tee[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+	}
+
+	Fanout(ctx, in, outs...)
+	return result
 }
@@ -21,6 +21,8 @@ package concurrency_test
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -28,6 +30,7 @@ import (
 	"github.com/andrejacobs/go-aj/concurrency"
 	"github.com/andrejacobs/go-aj/random"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFanout(t *testing.T) {
@@ -49,7 +52,7 @@ func TestFanout(t *testing.T) {
 	}()
 
 	// Duplicate the producer to output to multiple channels
-	go concurrency.Fanout(context.Background(), producer, consumers...)
+	done := concurrency.Fanout(context.Background(), producer, consumers...)
 
 	// Consume from all the duplicate producers
 	wg := sync.WaitGroup{}
@@ -70,6 +73,7 @@ func TestFanout(t *testing.T) {
 	}
 
 	wg.Wait()
+	assert.NoError(t, <-done)
 }
 
 func TestTransformedFanout(t *testing.T) {
@@ -91,7 +95,7 @@ func TestTransformedFanout(t *testing.T) {
 	}()
 
 	// Duplicate the producer to output to multiple channels
-	go concurrency.TransformedFanout(context.Background(),
+	done := concurrency.TransformedFanout(context.Background(),
 		func(in int) int {
 			return in * 2
 		},
@@ -116,6 +120,7 @@ func TestTransformedFanout(t *testing.T) {
 	}
 
 	wg.Wait()
+	assert.NoError(t, <-done)
 }
 
 func TestFanoutWithTimeout(t *testing.T) {
@@ -139,7 +144,7 @@ func TestFanoutWithTimeout(t *testing.T) {
 	// Duplicate the producer to output to multiple channels
 	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
 	defer cancel()
-	go concurrency.Fanout(ctx, producer, consumers...)
+	done := concurrency.Fanout(ctx, producer, consumers...)
 
 	// Consume from all the duplicate producers
 	wg := sync.WaitGroup{}
@@ -155,6 +160,7 @@ func TestFanoutWithTimeout(t *testing.T) {
 	}
 
 	wg.Wait()
+	assert.ErrorIs(t, <-done, context.DeadlineExceeded)
 }
 
 func TestFanoutDifferentRates(t *testing.T) {
@@ -177,7 +183,7 @@ func TestFanoutDifferentRates(t *testing.T) {
 	}()
 
 	// Duplicate the producer to output to multiple channels
-	go concurrency.Fanout(context.Background(), producer, consumers...)
+	done := concurrency.Fanout(context.Background(), producer, consumers...)
 
 	// Consume from all the duplicate producers
 	wg := sync.WaitGroup{}
@@ -200,4 +206,132 @@ func TestFanoutDifferentRates(t *testing.T) {
 	}
 
 	wg.Wait()
+	assert.NoError(t, <-done)
+}
+
+func TestFanoutCanceledWhileBlocked(t *testing.T) {
+	producer := make(chan int)
+	fast := make(chan int)
+	stuck := make(chan int) // never consumed
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	errStop := errors.New("stop")
+	done := concurrency.Fanout(ctx, producer, fast, stuck)
+
+	producer <- 1
+	assert.Equal(t, 1, <-fast)
+	cancel(errStop)
+
+	// Both consumers are closed even though one never received a value
+	require.ErrorIs(t, <-done, errStop)
+	_, ok := <-fast
+	assert.False(t, ok)
+	_, ok = <-stuck
+	assert.False(t, ok)
+
+	// The done channel is closed after the reason was received
+	_, ok = <-done
+	assert.False(t, ok)
+}
+
+func TestTee(t *testing.T) {
+	expectedCount := 1000
+	producer := make(chan int)
+	go func() {
+		for i := 0; i < expectedCount; i++ {
+			producer <- i
+		}
+		close(producer)
+	}()
+
+	outs := concurrency.Tee(context.Background(), producer, 3)
+	require.Len(t, outs, 3)
+
+	wg := sync.WaitGroup{}
+	for _, out := range outs {
+		wg.Add(1)
+		go func(consumer <-chan int) {
+			defer wg.Done()
+			received := make([]int, 0, expectedCount)
+			for v := range consumer {
+				received = append(received, v)
+			}
+			assert.Equal(t, expectedCount, len(received))
+			for i := 0; i < len(received); i++ {
+				assert.Equal(t, i, received[i])
+			}
+		}(out)
+	}
+	wg.Wait()
+}
+
+func TestTeeCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	producer := make(chan int)
+	outs := concurrency.Tee(ctx, producer, 2)
+	cancel()
+
+	for _, out := range outs {
+		for range out {
+		}
+	}
+}
+
+func TestTransformedFanoutWithErrors(t *testing.T) {
+	producer := make(chan string)
+	go func() {
+		for _, s := range []string{"1", "2", "x", "3", "y"} {
+			producer <- s
+		}
+		close(producer)
+	}()
+
+	a := make(chan int, 10)
+	b := make(chan int, 10)
+	errs := make(chan error, 10)
+	done := concurrency.TransformedFanoutWithErrors(context.Background(), strconv.Atoi, producer, errs,
+		concurrency.FanoutConsumer[int]{Out: a},
+		concurrency.FanoutConsumer[int]{Out: b, Policy: concurrency.DropNewest})
+
+	require.NoError(t, <-done)
+	assert.Equal(t, []int{1, 2, 3}, drain(a))
+	assert.Equal(t, []int{1, 2, 3}, drain(b))
+
+	received := drain(errs)
+	require.Len(t, received, 2)
+	for _, err := range received {
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+	}
+}
+
+func TestTransformedFanoutWithErrorsDropped(t *testing.T) {
+	producer := make(chan string, 3)
+	producer <- "1"
+	producer <- "x"
+	producer <- "2"
+	close(producer)
+
+	out := make(chan int, 10)
+	done := concurrency.TransformedFanoutWithErrors(context.Background(), strconv.Atoi, producer, nil,
+		concurrency.FanoutConsumer[int]{Out: out})
+
+	require.NoError(t, <-done)
+	assert.Equal(t, []int{1, 2}, drain(out))
+}
+
+func TestTransformedFanoutWithErrorsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	producer := make(chan string, 1)
+	producer <- "x"
+
+	// Nobody receives the error
+	errs := make(chan error)
+	out := make(chan int)
+	done := concurrency.TransformedFanoutWithErrors(ctx, strconv.Atoi, producer, errs,
+		concurrency.FanoutConsumer[int]{Out: out})
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Empty(t, drain(out))
 }
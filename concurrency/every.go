@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Every was called with an interval that is not positive.
+var ErrInvalidInterval = errors.New("concurrency: interval must be positive")
+
+// Every calls fn in a new goroutine every interval until the context is done.
+// If immediate is true then fn is also called straight away instead of only after the first interval.
+// fn is never called concurrently and ticks are dropped when fn takes longer than the interval.
+//
+// Errors returned by fn (and panics which are converted to a [PanicError]) are produced to the returned
+// channel which must be consumed, otherwise the next call to fn is blocked until the error is received.
+// The returned channel is closed once the context is done.
+// If the interval is not positive then fn is never called and the channel only receives [ErrInvalidInterval]
+// before it is closed.
+func Every(ctx context.Context, interval time.Duration, immediate bool, fn func(ctx context.Context) error) <-chan error {
+	if interval <= 0 {
+		errs := make(chan error, 1)
+		errs <- fmt.Errorf("failed to start with the interval %v. %w", interval, ErrInvalidInterval)
+		close(errs)
+		return errs
+	}
+
+	errs := make(chan error)
+
+	go func() {
+		defer close(errs)
+
+		run := func() bool {
+			err := callRecover(func() error { return fn(ctx) })
+			if err == nil {
+				return true
+			}
+			select {
+			case <-ctx.Done():
+				return false
+			case errs <- err:
+				return true
+			}
+		}
+
+		if immediate && ctx.Err() == nil {
+			if !run() {
+				return
+			}
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if ctx.Err() != nil || !run() {
+					return
+				}
+			}
+		}
+	}()
+
+	return errs
+}
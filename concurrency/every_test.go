@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errOdd := errors.New("odd")
+
+	var calls atomic.Int32
+	errs := concurrency.Every(ctx, 5*time.Millisecond, false, func(ctx context.Context) error {
+		if calls.Add(1)%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, <-errs, errOdd)
+	}
+	cancel()
+
+	for range errs {
+	}
+	assert.GreaterOrEqual(t, calls.Load(), int32(5))
+}
+
+func TestEveryImmediate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	called := make(chan time.Time, 1)
+	start := time.Now()
+	errs := concurrency.Every(ctx, time.Hour, true, func(ctx context.Context) error {
+		called <- time.Now()
+		return nil
+	})
+
+	select {
+	case at := <-called:
+		assert.Less(t, at.Sub(start), time.Second)
+	case <-time.After(time.Second):
+		t.Fatal("expected fn to be called immediately")
+	}
+
+	cancel()
+	_, ok := <-errs
+	assert.False(t, ok)
+}
+
+func TestEveryPanic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := concurrency.Every(ctx, time.Hour, true, func(ctx context.Context) error {
+		panic("tick")
+	})
+
+	var panicErr *concurrency.PanicError
+	require.ErrorAs(t, <-errs, &panicErr)
+	assert.Equal(t, "tick", panicErr.Value)
+}
+
+func TestEveryInvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		var calls atomic.Int32
+		errs := concurrency.Every(context.Background(), interval, true, func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})
+
+		err, ok := <-errs
+		require.True(t, ok)
+		assert.ErrorIs(t, err, concurrency.ErrInvalidInterval)
+
+		_, ok = <-errs
+		assert.False(t, ok)
+		assert.Equal(t, int32(0), calls.Load())
+	}
+}
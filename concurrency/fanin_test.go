@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanIn(t *testing.T) {
+	producerCount := 10
+	perProducer := 1000
+
+	producers := make([]<-chan int, producerCount)
+	for p := 0; p < producerCount; p++ {
+		ch := make(chan int, 10)
+		producers[p] = ch
+		go func(base int) {
+			for i := 0; i < perProducer; i++ {
+				ch <- base + i
+			}
+			close(ch)
+		}(p * perProducer)
+	}
+
+	received := make([]int, 0, producerCount*perProducer)
+	for v := range concurrency.FanIn(context.Background(), producers...) {
+		received = append(received, v)
+	}
+
+	slices.Sort(received)
+	assert.Len(t, received, producerCount*perProducer)
+	for i := range received {
+		assert.Equal(t, i, received[i])
+	}
+}
+
+func TestFanInNoInputs(t *testing.T) {
+	_, ok := <-concurrency.FanIn[int](context.Background())
+	assert.False(t, ok)
+}
+
+func TestFanInCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Producer that never closes
+	producer := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case producer <- i:
+			}
+		}
+	}()
+
+	out := concurrency.FanIn(ctx, producer)
+	<-out
+	cancel()
+
+	for range out {
+	}
+}
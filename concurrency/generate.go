@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+)
+
+// Generate calls next in a new goroutine and produces the values it returns to the returned value channel,
+// turning a pull style iterator into a pipeline source. The next value is only pulled once the previous
+// value has been received, meaning a slow consumer slows down the generator.
+//
+// next returns false once it is exhausted, after which the value channel is closed.
+// If next returns an error (or panics which is converted to a [PanicError]) then generating is stopped and
+// the error is produced to the returned error channel, which has room for the error and thus doesn't need to
+// be consumed. Both channels are closed once generating stops, including when the context is canceled.
+func Generate[T any](ctx context.Context, next func() (T, bool, error)) (<-chan T, <-chan error) {
+	out := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(out)
+
+		for ctx.Err() == nil {
+			var v T
+			var ok bool
+			err := callRecover(func() error {
+				var err error
+				v, ok, err = next()
+				return err
+			})
+
+			if err != nil {
+				errs <- err
+				return
+			}
+			if !ok {
+				return
+			}
+			if Send(ctx, out, v) != nil {
+				return
+			}
+		}
+	}()
+
+	return out, errs
+}
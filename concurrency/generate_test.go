@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func counter(limit int, err error) func() (int, bool, error) {
+	i := 0
+	return func() (int, bool, error) {
+		if i == limit {
+			return 0, false, err
+		}
+		i++
+		return i, true, nil
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	out, errs := concurrency.Generate(context.Background(), counter(5, nil))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, drain(out))
+	assert.Empty(t, drain(errs))
+}
+
+func TestGenerateError(t *testing.T) {
+	errBad := errors.New("bad")
+	out, errs := concurrency.Generate(context.Background(), counter(3, errBad))
+	assert.Equal(t, []int{1, 2, 3}, drain(out))
+	require.ErrorIs(t, <-errs, errBad)
+
+	out, errs = concurrency.Generate(context.Background(), func() (int, bool, error) {
+		panic("oops")
+	})
+	assert.Empty(t, drain(out))
+	var panicErr *concurrency.PanicError
+	assert.ErrorAs(t, <-errs, &panicErr)
+}
+
+func TestGenerateBackpressure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var pulled atomic.Int32
+	out, _ := concurrency.Generate(ctx, func() (int32, bool, error) {
+		return pulled.Add(1), true, nil
+	})
+
+	assert.Equal(t, int32(1), <-out)
+	assert.Equal(t, int32(2), <-out)
+
+	// At most one value is pulled ahead of the consumer
+	time.Sleep(10 * time.Millisecond)
+	assert.LessOrEqual(t, pulled.Load(), int32(3))
+}
+
+func TestGenerateCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out, errs := concurrency.Generate(ctx, counter(-1, nil))
+	<-out
+	cancel()
+
+	for range out {
+	}
+	assert.Empty(t, drain(errs))
+}
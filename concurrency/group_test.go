@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	g := concurrency.NewGroup(3)
+
+	var active atomic.Int32
+	var count atomic.Int32
+	for i := 0; i < 50; i++ {
+		g.Go(func() error {
+			assert.LessOrEqual(t, active.Add(1), int32(3))
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+			count.Add(1)
+			return nil
+		})
+	}
+
+	require.NoError(t, g.Wait())
+	assert.Equal(t, int32(50), count.Load())
+}
+
+func TestGroupCollectsAllErrors(t *testing.T) {
+	var g concurrency.Group
+	errBad := errors.New("bad")
+
+	for i := 0; i < 10; i++ {
+		g.Go(func() error {
+			switch {
+			case i == 7:
+				panic(fmt.Sprintf("panic %d", i))
+			case i%3 == 0:
+				return fmt.Errorf("item %d. %w", i, errBad)
+			}
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errBad)
+
+	var panicErr *concurrency.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "panic 7", panicErr.Value)
+
+	// 0, 3, 6, 9 and the panic
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 5)
+}
+
+func TestGroupTryGo(t *testing.T) {
+	g := concurrency.NewGroup(1)
+	release := make(chan struct{})
+
+	assert.True(t, g.TryGo(func() error {
+		<-release
+		return nil
+	}))
+	assert.False(t, g.TryGo(func() error { return nil }))
+
+	close(release)
+	require.NoError(t, g.Wait())
+	assert.True(t, g.TryGo(func() error { return nil }))
+	require.NoError(t, g.Wait())
+}
+
+func TestGroupWithContext(t *testing.T) {
+	errFirst := errors.New("first")
+	g, ctx := concurrency.NewGroupWithContext(context.Background(), 0)
+
+	g.Go(func() error {
+		return errFirst
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		return context.Cause(ctx)
+	})
+
+	err := g.Wait()
+	assert.ErrorIs(t, err, errFirst)
+	assert.ErrorIs(t, context.Cause(ctx), errFirst)
+
+	// Canceled once Wait returns even without errors
+	g, ctx = concurrency.NewGroupWithContext(context.Background(), 2)
+	g.Go(func() error { return nil })
+	require.NoError(t, g.Wait())
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+)
+
+// MapOption is used to configure [Map].
+type MapOption func(o *mapOptions)
+
+type mapOptions struct {
+	collectErrors bool
+}
+
+// Continue processing all the items when an error occurs and return all the errors joined
+// using errors.Join instead of stopping at the first error.
+func WithCollectErrors() MapOption {
+	return func(o *mapOptions) {
+		o.collectErrors = true
+	}
+}
+
+// Map calls fn for every item using the specified number of workers and returns the results in the
+// same order as the items. If workers is less than 1 then the number of items is used.
+//
+// By default the first error (or panic which is converted to a [PanicError]) cancels the context passed
+// to the remaining calls and is returned along with the partial results.
+// Use [WithCollectErrors] to process all the items and return every error.
+// Errors are wrapped to include the index of the item that failed.
+func Map[In any, Out any](ctx context.Context, items []In, workers int,
+	fn func(ctx context.Context, item In) (Out, error), opts ...MapOption) ([]Out, error) {
+	var o mapOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if workers < 1 {
+		workers = len(items)
+	}
+
+	var g *Group
+	gctx := ctx
+	if o.collectErrors {
+		g = NewGroup(workers)
+	} else {
+		g, gctx = NewGroupWithContext(ctx, workers)
+	}
+
+	results := make([]Out, len(items))
+	for i, item := range items {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return nil
+			}
+			out, err := fn(gctx, item)
+			if err != nil {
+				return fmt.Errorf("failed to map item %d. %w", i, err)
+			}
+			results[i] = out
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	if o.collectErrors {
+		return results, err
+	}
+	if err != nil || ctx.Err() != nil {
+		// Only the first error matters, the others are likely a result of the cancellation
+		return results, context.Cause(gctx)
+	}
+	return results, nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/andrejacobs/go-aj/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMap(t *testing.T) {
+	items := make([]int, 500)
+	for i := range items {
+		items[i] = i
+	}
+
+	results, err := concurrency.Map(context.Background(), items, 8, func(ctx context.Context, item int) (string, error) {
+		time.Sleep(time.Duration(random.Int(0, 100)) * time.Microsecond)
+		return fmt.Sprint(item * 2), nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, len(items))
+	for i, r := range results {
+		assert.Equal(t, fmt.Sprint(i*2), r)
+	}
+
+	empty, err := concurrency.Map(context.Background(), []int{}, 0, func(ctx context.Context, item int) (int, error) {
+		return item, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestMapFailFast(t *testing.T) {
+	errBad := errors.New("bad")
+	var calls atomic.Int32
+
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+
+	_, err := concurrency.Map(context.Background(), items, 2, func(ctx context.Context, item int) (int, error) {
+		calls.Add(1)
+		if item == 10 {
+			return 0, errBad
+		}
+		return item, nil
+	})
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, "failed to map item 10. bad", err.Error())
+	assert.Less(t, calls.Load(), int32(1000))
+}
+
+func TestMapCollectErrors(t *testing.T) {
+	errBad := errors.New("bad")
+
+	results, err := concurrency.Map(context.Background(), []int{1, 2, 3, 4, 5, 6}, 3,
+		func(ctx context.Context, item int) (int, error) {
+			if item%2 == 0 {
+				return 0, errBad
+			}
+			if item == 5 {
+				panic("five")
+			}
+			return item * 10, nil
+		}, concurrency.WithCollectErrors())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errBad)
+	var panicErr *concurrency.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 4)
+	assert.Equal(t, []int{10, 0, 30, 0, 0, 0}, results)
+}
+
+func TestMapCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := concurrency.Map(ctx, []int{1, 2, 3}, 1, func(ctx context.Context, item int) (int, error) {
+		return item, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
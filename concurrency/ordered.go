@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"sync"
+)
+
+// Sequenced is a value (or error) along with its position in the original input order.
+type Sequenced[T any] struct {
+	Seq   uint64 // position (starting at 0) of the input from which Value was produced
+	Value T      // result of the processing function
+	Err   error  // error returned by the processing function (or a [PanicError])
+}
+
+// OrderedStage is like [Stage] except that the results are produced to the returned channel
+// in the same order as the values were consumed from the 'in' channel, even though they are processed
+// in parallel by the specified number of workers.
+// Errors are produced inline with the results so that the caller can decide whether to continue.
+//
+// At most 2x workers values are in flight at any time, meaning a slow value will eventually stall
+// the workers until it has been produced instead of results piling up in memory.
+// The returned channel is closed once the 'in' channel has been closed (or the context is canceled)
+// and all the in flight values have been produced.
+func OrderedStage[In any, Out any](ctx context.Context, in <-chan In, workers int,
+	fn func(in In) (Out, error)) <-chan Sequenced[Out] {
+	workers = max(workers, 1)
+	window := make(chan struct{}, workers*2)
+	work := make(chan Sequenced[In])
+	results := make(chan Sequenced[Out])
+	out := make(chan Sequenced[Out])
+
+	// Assign sequence numbers
+	go func() {
+		defer close(work)
+		var seq uint64
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case window <- struct{}{}:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case data, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case work <- Sequenced[In]{Seq: seq, Value: data}:
+				}
+				seq++
+			}
+		}
+	}()
+
+	// Process in parallel
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				result := Sequenced[Out]{Seq: item.Seq}
+				result.Err = callRecover(func() error {
+					var err error
+					result.Value, err = fn(item.Value)
+					return err
+				})
+
+				select {
+				case <-ctx.Done():
+					return
+				case results <- result:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Restore the original order
+	go func() {
+		defer close(out)
+		pending := make(map[uint64]Sequenced[Out], cap(window))
+		var next uint64
+		for result := range results {
+			pending[result.Seq] = result
+			for {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				select {
+				case <-ctx.Done():
+					// Keep draining so that the workers can exit
+					for range results {
+					}
+					return
+				case out <- r:
+				}
+				delete(pending, next)
+				next++
+				<-window
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/andrejacobs/go-aj/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedStage(t *testing.T) {
+	expectedCount := 1000
+	errOdd := errors.New("odd")
+
+	source := make(chan int)
+	go func() {
+		for i := 0; i < expectedCount; i++ {
+			source <- i
+		}
+		close(source)
+	}()
+
+	out := concurrency.OrderedStage(context.Background(), source, 8, func(v int) (int, error) {
+		// Finish out of order
+		time.Sleep(time.Duration(random.Int(0, 100)) * time.Microsecond)
+		if v%100 == 99 {
+			return 0, errOdd
+		}
+		if v == 500 {
+			panic("halfway")
+		}
+		return v * 10, nil
+	})
+
+	count := 0
+	for r := range out {
+		require.Equal(t, uint64(count), r.Seq)
+		switch {
+		case count%100 == 99:
+			assert.ErrorIs(t, r.Err, errOdd)
+		case count == 500:
+			var panicErr *concurrency.PanicError
+			assert.ErrorAs(t, r.Err, &panicErr)
+		default:
+			assert.NoError(t, r.Err)
+			assert.Equal(t, count*10, r.Value)
+		}
+		count++
+	}
+	assert.Equal(t, expectedCount, count)
+}
+
+func TestOrderedStageCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	source := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case source <- i:
+			}
+		}
+	}()
+
+	out := concurrency.OrderedStage(ctx, source, 4, func(v int) (int, error) {
+		return v, nil
+	})
+	r := <-out
+	assert.Equal(t, uint64(0), r.Seq)
+	cancel()
+
+	for range out {
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"sync"
+)
+
+// Stage starts a pipeline stage that consumes from the 'in' channel using the specified number of workers
+// and produces the result of calling fn to the returned output channel.
+// Errors returned by fn (and panics which are converted to a [PanicError]) are produced to the returned
+// error channel and the value is dropped. The order of values is not preserved when workers is more than 1.
+//
+// Both of the returned channels are closed once the 'in' channel has been closed (or the context is canceled)
+// and all the workers have finished. Both channels must be consumed, otherwise the workers will block.
+// The output channel can be used as the input to the next stage and the error channels from
+// multiple stages can be merged using [FanIn].
+func Stage[In any, Out any](ctx context.Context, in <-chan In, workers int,
+	fn func(in In) (Out, error)) (<-chan Out, <-chan error) {
+	workers = max(workers, 1)
+	out := make(chan Out)
+	errs := make(chan error)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case data, ok := <-in:
+					if !ok {
+						return
+					}
+
+					var result Out
+					err := callRecover(func() error {
+						var err error
+						result, err = fn(data)
+						return err
+					})
+
+					if err != nil {
+						select {
+						case <-ctx.Done():
+							return
+						case errs <- err:
+						}
+						continue
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case out <- result:
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+		close(errs)
+	}()
+
+	return out, errs
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStage(t *testing.T) {
+	ctx := context.Background()
+	errInvalid := errors.New("invalid")
+
+	source := make(chan string)
+	go func() {
+		for i := 0; i < 100; i++ {
+			source <- strconv.Itoa(i)
+		}
+		source <- "not a number"
+		close(source)
+	}()
+
+	parsed, errs1 := concurrency.Stage(ctx, source, 4, func(s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	doubled, errs2 := concurrency.Stage(ctx, parsed, 3, func(v int) (int, error) {
+		if v == 13 {
+			return 0, fmt.Errorf("unlucky %d. %w", v, errInvalid)
+		}
+		if v == 42 {
+			panic("the answer")
+		}
+		return v * 2, nil
+	})
+
+	var allErrs []error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for err := range concurrency.FanIn(ctx, errs1, errs2) {
+			allErrs = append(allErrs, err)
+		}
+	}()
+
+	var received []int
+	for v := range doubled {
+		received = append(received, v)
+	}
+	wg.Wait()
+
+	slices.Sort(received)
+	require.Len(t, received, 98)
+	assert.Equal(t, 0, received[0])
+	assert.Equal(t, 198, received[97])
+	assert.NotContains(t, received, 26)
+	assert.NotContains(t, received, 84)
+
+	require.Len(t, allErrs, 3)
+	err := errors.Join(allErrs...)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.ErrorIs(t, err, errInvalid)
+	var panicErr *concurrency.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+}
+
+func TestStageCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	source := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case source <- i:
+			}
+		}
+	}()
+
+	out, errs := concurrency.Stage(ctx, source, 2, func(v int) (int, error) {
+		return v, nil
+	})
+	<-out
+	cancel()
+
+	for range out {
+	}
+	for range errs {
+	}
+}
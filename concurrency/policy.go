@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"sync"
+)
+
+// FanoutPolicy determines what happens when a consumer channel is not ready to receive a value.
+type FanoutPolicy int
+
+const (
+	// Block until the consumer receives the value. This slows all the consumers down to the speed of the slowest.
+	Block FanoutPolicy = iota
+	// Drop the new value when the consumer's buffer is full.
+	DropNewest
+	// Discard the oldest buffered value to make room for the new value when the consumer's buffer is full.
+	DropOldest
+	// Discard all the buffered values when the consumer's buffer is full so that only the new value remains.
+	LatestOnly
+)
+
+// FanoutConsumer is a consumer channel along with the policy used to deliver values to it.
+// The non-blocking policies need a buffered channel (or a QueueSize), an unbuffered channel will only
+// receive values while the consumer is waiting to receive.
+//
+// When QueueSize is more than 0 the consumer is decoupled from the others by its own goroutine that
+// forwards values from an internal queue of QueueSize values to Out. Consumers at different speeds then
+// only hold each other back once a queue is full, at which point the Policy is applied to the queue.
+// Completion is only signalled once the queued values have been received by the consumer.
+type FanoutConsumer[T any] struct {
+	Out       chan T
+	Policy    FanoutPolicy
+	QueueSize int
+}
+
+// Replace the consumers that need a queue with one that is forwarded to the consumer by a goroutine.
+// The forwarding goroutines close the consumer's channel once the queue is closed (or the context is canceled)
+// and the returned WaitGroup is used to wait for this.
+func decouple[T any](ctx context.Context, consumers []FanoutConsumer[T]) ([]FanoutConsumer[T], *sync.WaitGroup) {
+	var wg sync.WaitGroup
+	result := make([]FanoutConsumer[T], len(consumers))
+	for i, c := range consumers {
+		if c.QueueSize <= 0 {
+			result[i] = c
+			continue
+		}
+
+		queue := make(chan T, c.QueueSize)
+		result[i] = FanoutConsumer[T]{Out: queue, Policy: c.Policy}
+
+		wg.Add(1)
+		go func(out chan T) {
+			defer wg.Done()
+			defer close(out)
+			for v := range queue {
+				select {
+				case <-ctx.Done():
+					return
+				case out <- v:
+				}
+			}
+		}(c.Out)
+	}
+	return result, &wg
+}
+
+// Deliver the value to the out channel according to the policy.
+// Returns false if the value was dropped or the context was canceled while blocked.
+func deliver[T any](ctx context.Context, out chan T, v T, policy FanoutPolicy) bool {
+	if policy == Block {
+		select {
+		case <-ctx.Done():
+			return false
+		case out <- v:
+			return true
+		}
+	}
+
+	for {
+		select {
+		case out <- v:
+			return true
+		default:
+		}
+
+		if policy == DropNewest || cap(out) == 0 {
+			return false
+		}
+
+		// Make room. The consumer might have received in the meantime which is fine
+		drained := false
+		for !drained {
+			select {
+			case <-out:
+				drained = policy == DropOldest
+			default:
+				drained = true
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFanoutWithPolicy(t *testing.T) {
+	expectedCount := 100
+	producer := make(chan int)
+
+	fast := make(chan int, 10)
+	dropNewest := make(chan int, 3)
+	dropOldest := make(chan int, 3)
+	latest := make(chan int, 3)
+	unbuffered := make(chan int)
+
+	done := concurrency.FanoutWithPolicy(context.Background(), producer,
+		concurrency.FanoutConsumer[int]{Out: fast},
+		concurrency.FanoutConsumer[int]{Out: dropNewest, Policy: concurrency.DropNewest},
+		concurrency.FanoutConsumer[int]{Out: dropOldest, Policy: concurrency.DropOldest},
+		concurrency.FanoutConsumer[int]{Out: latest, Policy: concurrency.LatestOnly},
+		concurrency.FanoutConsumer[int]{Out: unbuffered, Policy: concurrency.DropOldest},
+	)
+
+	// Only the fast consumer is receiving while producing
+	received := make([]int, 0, expectedCount)
+	for i := 0; i < expectedCount; i++ {
+		producer <- i
+		received = append(received, <-fast)
+	}
+	close(producer)
+	require.NoError(t, <-done)
+
+	_, ok := <-fast
+	assert.False(t, ok)
+	for i := 0; i < expectedCount; i++ {
+		assert.Equal(t, i, received[i])
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, drain(dropNewest))
+	assert.Equal(t, []int{97, 98, 99}, drain(dropOldest))
+	assert.Equal(t, []int{99}, drain(latest))
+	assert.Empty(t, drain(unbuffered))
+}
+
+func TestTransformedFanoutWithPolicy(t *testing.T) {
+	producer := make(chan int)
+	latest := make(chan string, 1)
+
+	done := concurrency.TransformedFanoutWithPolicy(context.Background(),
+		func(in int) string { return string(rune('a' + in)) },
+		producer,
+		concurrency.FanoutConsumer[string]{Out: latest, Policy: concurrency.LatestOnly})
+
+	for i := 0; i < 26; i++ {
+		producer <- i
+	}
+	close(producer)
+	require.NoError(t, <-done)
+
+	assert.Equal(t, []string{"z"}, drain(latest))
+}
+
+func drain[T any](ch <-chan T) []T {
+	var result []T
+	for v := range ch {
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestFanoutConsumerQueueSize(t *testing.T) {
+	expectedCount := 50
+	producer := make(chan int)
+	go func() {
+		for i := 0; i < expectedCount; i++ {
+			producer <- i
+		}
+		close(producer)
+	}()
+
+	fast := make(chan int)
+	slow := make(chan int)
+	done := concurrency.FanoutWithPolicy(context.Background(), producer,
+		concurrency.FanoutConsumer[int]{Out: fast, QueueSize: expectedCount},
+		concurrency.FanoutConsumer[int]{Out: slow, QueueSize: expectedCount},
+	)
+
+	// The fast consumer is not held back by the slow consumer that hasn't started receiving yet
+	assert.Equal(t, expectedCount, len(drain(fast)))
+
+	received := make([]int, 0, expectedCount)
+	for v := range slow {
+		received = append(received, v)
+		time.Sleep(time.Millisecond)
+	}
+	require.NoError(t, <-done)
+	for i := 0; i < expectedCount; i++ {
+		assert.Equal(t, i, received[i])
+	}
+}
+
+func TestFanoutConsumerQueueSizeWithPolicy(t *testing.T) {
+	producer := make(chan int)
+	slow := make(chan int)
+	done := concurrency.FanoutWithPolicy(context.Background(), producer,
+		concurrency.FanoutConsumer[int]{Out: slow, QueueSize: 2, Policy: concurrency.DropNewest},
+	)
+
+	for i := 0; i < 10; i++ {
+		producer <- i
+	}
+	close(producer)
+
+	// The forwarder holds one value while blocked on the consumer and the queue the next 2.
+	// The last value sent might still be in flight and is queued once the consumer starts receiving.
+	received := drain(slow)
+	require.NoError(t, <-done)
+	assert.LessOrEqual(t, len(received), 4)
+	assert.Equal(t, 0, received[0])
+}
+
+func TestFanoutConsumerQueueSizeCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	producer := make(chan int)
+	slow := make(chan int)
+	done := concurrency.FanoutWithPolicy(ctx, producer,
+		concurrency.FanoutConsumer[int]{Out: slow, QueueSize: 10},
+	)
+
+	producer <- 1
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	_, ok := <-slow
+	assert.False(t, ok)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Submit was called after Wait.
+var ErrPoolClosed = errors.New("concurrency: pool is closed")
+
+// Pool runs a work function over submitted items using a bounded number of workers.
+// Errors returned by the work function (and panics which are converted to a [PanicError])
+// are collected and returned by Wait.
+type Pool[T any] struct {
+	ctx  context.Context
+	fn   func(ctx context.Context, item T) error
+	work chan T
+	wg   sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+	errs   []error
+}
+
+// Create a new Pool that starts the specified number of workers which call fn for every submitted item.
+// If workers is less than 1 then a single worker is used.
+// The workers stop when the context is canceled.
+func NewPool[T any](ctx context.Context, workers int, fn func(ctx context.Context, item T) error) *Pool[T] {
+	workers = max(workers, 1)
+	p := &Pool[T]{
+		ctx:  ctx,
+		fn:   fn,
+		work: make(chan T),
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+// Submit an item to be processed by the next available worker.
+// Blocks until a worker accepts the item or the context is canceled.
+// Returns [ErrPoolClosed] if Wait has already been called.
+func (p *Pool[T]) Submit(item T) error {
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	case p.work <- item:
+		return nil
+	}
+}
+
+// Wait stops accepting new items, waits for all the workers to finish and returns all the errors
+// that occurred joined using errors.Join. The context error is included if it was canceled.
+// Submit must not be called concurrently with or after Wait.
+func (p *Pool[T]) Wait() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.work)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	errs := p.errs
+	if err := p.ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Pool[T]) worker() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case item, ok := <-p.work:
+			if !ok {
+				return
+			}
+			if err := callRecover(func() error { return p.fn(p.ctx, item) }); err != nil {
+				p.mu.Lock()
+				p.errs = append(p.errs, err)
+				p.mu.Unlock()
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool(t *testing.T) {
+	var sum atomic.Int64
+	var active atomic.Int32
+	var maxActive atomic.Int32
+
+	p := concurrency.NewPool(context.Background(), 4, func(ctx context.Context, item int) error {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			m := maxActive.Load()
+			if n <= m || maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		sum.Add(int64(item))
+		return nil
+	})
+
+	for i := 1; i <= 1000; i++ {
+		require.NoError(t, p.Submit(i))
+	}
+	require.NoError(t, p.Wait())
+
+	assert.Equal(t, int64(500500), sum.Load())
+	assert.LessOrEqual(t, maxActive.Load(), int32(4))
+
+	assert.ErrorIs(t, p.Submit(1), concurrency.ErrPoolClosed)
+	// Calling Wait again is fine
+	require.NoError(t, p.Wait())
+}
+
+func TestPoolErrorsAndPanics(t *testing.T) {
+	errOdd := errors.New("odd")
+	p := concurrency.NewPool(context.Background(), 2, func(ctx context.Context, item int) error {
+		if item == 5 {
+			panic("five")
+		}
+		if item%2 == 1 {
+			return fmt.Errorf("item %d. %w", item, errOdd)
+		}
+		return nil
+	})
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, p.Submit(i))
+	}
+	err := p.Wait()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errOdd)
+
+	var panicErr *concurrency.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "five", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+
+	// 1, 3, 7, 9 and the panic
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 5)
+}
+
+func TestPoolCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	p := concurrency.NewPool(ctx, 1, func(ctx context.Context, item int) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	})
+
+	require.NoError(t, p.Submit(1))
+	<-started
+	cancel()
+
+	assert.ErrorIs(t, p.Submit(2), context.Canceled)
+	assert.ErrorIs(t, p.Wait(), context.Canceled)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"reflect"
+)
+
+// PriorityFanIn consumes from all of the 'in' channels and produces the values to a single output channel,
+// always preferring the earliest channel in the list that has a value ready. A later channel is only
+// consumed from when all the earlier channels are empty, meaning a busy channel can starve the later ones.
+// The output channel is closed once all of the input channels have been closed or the context is canceled.
+func PriorityFanIn[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	return WeightedFanIn(ctx, nil, ins...)
+}
+
+// WeightedFanIn consumes from all of the 'in' channels and produces the values to a single output channel.
+// When multiple channels have values ready, each channel is given a share of the output in proportion to
+// its weight, for example weights of 3 and 1 means 3 values from the first channel for every value from the
+// second channel. Within a share the earliest channel in the list is preferred.
+// A channel is never left waiting when no other channel has a value ready.
+// If weights is nil then strict priority is used, see [PriorityFanIn]. Missing weights or weights less than 1 mean 1.
+// The output channel is closed once all of the input channels have been closed or the context is canceled.
+func WeightedFanIn[T any](ctx context.Context, weights []int, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+		m := newPriorityMux(ctx, weights, ins)
+		for {
+			v, ok := m.next()
+			if !ok {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- v:
+			}
+		}
+	}()
+
+	return out
+}
+
+type priorityMux[T any] struct {
+	ctx     context.Context
+	ins     []<-chan T // a closed channel is set to nil
+	open    int
+	weights []int // nil for strict priority
+	credits []int
+}
+
+func newPriorityMux[T any](ctx context.Context, weights []int, ins []<-chan T) *priorityMux[T] {
+	m := &priorityMux[T]{
+		ctx:  ctx,
+		ins:  append([]<-chan T(nil), ins...),
+		open: len(ins),
+	}
+
+	if weights != nil {
+		m.weights = make([]int, len(ins))
+		for i := range m.weights {
+			m.weights[i] = 1
+			if i < len(weights) && weights[i] > 1 {
+				m.weights[i] = weights[i]
+			}
+		}
+		m.credits = make([]int, len(ins))
+		copy(m.credits, m.weights)
+	}
+
+	return m
+}
+
+// Return the next value to be produced or false if all channels are closed or the context is done.
+func (m *priorityMux[T]) next() (T, bool) {
+	var zero T
+	for m.open > 0 && m.ctx.Err() == nil {
+		// Prefer the channels that still have credit
+		if v, i, ok := m.tryRecv(true); ok {
+			m.spend(i)
+			return v, true
+		}
+
+		// Every channel with a value ready has used its share
+		if m.weights != nil {
+			if v, i, ok := m.tryRecv(false); ok {
+				copy(m.credits, m.weights)
+				m.spend(i)
+				return v, true
+			}
+		}
+
+		// Nothing is ready, wait for any of the channels that are still open
+		if m.open == 0 {
+			break
+		}
+		v, i, ok := m.recv()
+		if ok {
+			m.spend(i)
+			return v, true
+		}
+	}
+	return zero, false
+}
+
+// Try to receive from the channels in order without blocking.
+// If withCredit is true then only the channels with credit remaining are considered.
+func (m *priorityMux[T]) tryRecv(withCredit bool) (T, int, bool) {
+	var zero T
+	for i, in := range m.ins {
+		if in == nil || (withCredit && m.credits != nil && m.credits[i] <= 0) {
+			continue
+		}
+		select {
+		case v, ok := <-in:
+			if ok {
+				return v, i, true
+			}
+			m.closeInput(i)
+		default:
+		}
+	}
+	return zero, -1, false
+}
+
+// Block until any of the channels produce a value, is closed or the context is done.
+func (m *priorityMux[T]) recv() (T, int, bool) {
+	var zero T
+	cases := make([]reflect.SelectCase, 0, len(m.ins)+1)
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(m.ctx.Done())})
+	for _, in := range m.ins {
+		c := reflect.SelectCase{Dir: reflect.SelectRecv}
+		if in != nil {
+			c.Chan = reflect.ValueOf(in)
+		}
+		cases = append(cases, c)
+	}
+
+	chosen, v, ok := reflect.Select(cases)
+	if chosen == 0 {
+		return zero, -1, false
+	}
+
+	i := chosen - 1
+	if !ok {
+		m.closeInput(i)
+		return zero, -1, false
+	}
+	// A nil interface value can't be asserted to T, it becomes the zero value instead
+	val, _ := v.Interface().(T)
+	return val, i, true
+}
+
+func (m *priorityMux[T]) closeInput(i int) {
+	m.ins[i] = nil
+	m.open--
+}
+
+func (m *priorityMux[T]) spend(i int) {
+	if m.credits == nil {
+		return
+	}
+	m.credits[i]--
+
+	// Start a new round once all the open channels have used their share
+	for j, c := range m.credits {
+		if m.ins[j] != nil && c > 0 {
+			return
+		}
+	}
+	copy(m.credits, m.weights)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+)
+
+func filledChannel(values ...string) <-chan string {
+	ch := make(chan string, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+func TestPriorityFanIn(t *testing.T) {
+	control := filledChannel("c1", "c2")
+	data := filledChannel("d1", "d2", "d3")
+
+	out := concurrency.PriorityFanIn(context.Background(), control, data)
+	assert.Equal(t, []string{"c1", "c2", "d1", "d2", "d3"}, drain(out))
+}
+
+func TestWeightedFanIn(t *testing.T) {
+	a := filledChannel("a1", "a2", "a3", "a4", "a5", "a6", "a7")
+	b := filledChannel("b1", "b2", "b3")
+
+	out := concurrency.WeightedFanIn(context.Background(), []int{3, 1}, a, b)
+	assert.Equal(t, []string{
+		"a1", "a2", "a3", "b1",
+		"a4", "a5", "a6", "b2",
+		"a7", "b3",
+	}, drain(out))
+
+	// Missing weights default to 1
+	a = filledChannel("a1", "a2", "a3")
+	b = filledChannel("b1", "b2", "b3")
+	out = concurrency.WeightedFanIn(context.Background(), []int{}, a, b)
+	assert.Equal(t, []string{"a1", "b1", "a2", "b2", "a3", "b3"}, drain(out))
+}
+
+func TestPriorityFanInBlocking(t *testing.T) {
+	high := make(chan int)
+	low := make(chan int)
+	out := concurrency.PriorityFanIn(context.Background(), high, low)
+
+	// Values arriving while the mux is waiting are passed through
+	go func() {
+		low <- 1
+		high <- 2
+		close(high)
+		low <- 3
+		close(low)
+	}()
+
+	assert.Equal(t, []int{1, 2, 3}, drain(out))
+}
+
+func TestPriorityFanInCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := concurrency.PriorityFanIn(ctx, make(chan int), make(chan int))
+	cancel()
+
+	_, ok := <-out
+	assert.False(t, ok)
+}
+
+func TestPriorityFanInNilInterface(t *testing.T) {
+	errTest := errors.New("test")
+	fanIns := map[string]func(ctx context.Context, ins ...<-chan error) <-chan error{
+		"priority": concurrency.PriorityFanIn[error],
+		"weighted": func(ctx context.Context, ins ...<-chan error) <-chan error {
+			return concurrency.WeightedFanIn(ctx, []int{2, 1}, ins...)
+		},
+	}
+
+	for name, fanIn := range fanIns {
+		t.Run(name, func(t *testing.T) {
+			high := make(chan error)
+			low := make(chan error)
+			out := fanIn(context.Background(), high, low)
+
+			// Give the mux time to block waiting for a value so that the nil values arrive on that path
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				high <- nil
+				time.Sleep(10 * time.Millisecond)
+				low <- errTest
+				close(high)
+				time.Sleep(10 * time.Millisecond)
+				low <- nil
+				close(low)
+			}()
+
+			assert.Equal(t, []error{nil, errTest, nil}, drain(out))
+		})
+	}
+}
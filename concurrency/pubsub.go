@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/andrejacobs/go-aj/matches"
+)
+
+// Subscribe or Publish was called after the PubSub was closed.
+var ErrPubSubClosed = errors.New("concurrency: pubsub is closed")
+
+// Message is a payload published to a topic.
+type Message[T any] struct {
+	Topic   string
+	Payload T
+}
+
+// PubSub routes published messages to the subscribers whose regular expressions match the topic.
+type PubSub[T any] struct {
+	mu     sync.RWMutex
+	subs   map[*Subscription[T]]struct{}
+	closed bool
+}
+
+// Subscription receives the messages published to the topics matching any of its regular expressions.
+type Subscription[T any] struct {
+	ps       *PubSub[T]
+	patterns *matches.RegexList
+	policy   FanoutPolicy
+	ch       chan Message[T]
+	done     chan struct{}
+	once     sync.Once
+
+	mu     sync.RWMutex // held for reading while sending to ch and for writing to close it
+	closed bool
+}
+
+// Create a new PubSub.
+func NewPubSub[T any]() *PubSub[T] {
+	return &PubSub[T]{
+		subs: make(map[*Subscription[T]]struct{}),
+	}
+}
+
+// Subscribe to the topics that match any of the regular expressions.
+// Messages are delivered to a channel with a buffer of bufferSize messages and the policy determines
+// what happens when the subscriber is not keeping up, see [FanoutPolicy].
+// Returns [ErrPubSubClosed] if the PubSub has been closed.
+func (ps *PubSub[T]) Subscribe(expressions []string, bufferSize int, policy FanoutPolicy) (*Subscription[T], error) {
+	patterns, err := matches.NewRegexList(expressions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe. %w", err)
+	}
+
+	s := &Subscription[T]{
+		ps:       ps,
+		patterns: patterns,
+		policy:   policy,
+		ch:       make(chan Message[T], max(bufferSize, 0)),
+		done:     make(chan struct{}),
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.closed {
+		return nil, ErrPubSubClosed
+	}
+	ps.subs[s] = struct{}{}
+	return s, nil
+}
+
+// Publish the payload to the topic and return the number of subscribers it was delivered to.
+// Blocks while delivering to subscribers using the [Block] policy until they receive the message,
+// unsubscribe or the context is done. Subscribing, unsubscribing and closing are not blocked meanwhile.
+// Returns the cause of the context cancellation if it was done before delivering to all the subscribers
+// and [ErrPubSubClosed] if the PubSub has been closed.
+func (ps *PubSub[T]) Publish(ctx context.Context, topic string, payload T) (int, error) {
+	ps.mu.RLock()
+	if ps.closed {
+		ps.mu.RUnlock()
+		return 0, ErrPubSubClosed
+	}
+
+	targets := make([]*Subscription[T], 0, len(ps.subs))
+	for s := range ps.subs {
+		if s.patterns.MatchesAny(topic) {
+			targets = append(targets, s)
+		}
+	}
+	ps.mu.RUnlock()
+
+	msg := Message[T]{Topic: topic, Payload: payload}
+	delivered := 0
+	for _, s := range targets {
+		ok, err := s.send(ctx, msg)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// Close the PubSub and all of its subscriptions.
+func (ps *PubSub[T]) Close() {
+	ps.mu.Lock()
+	if ps.closed {
+		ps.mu.Unlock()
+		return
+	}
+	ps.closed = true
+	subs := ps.subs
+	ps.subs = nil
+	ps.mu.Unlock()
+
+	for s := range subs {
+		s.close()
+	}
+}
+
+// C returns the channel on which the messages are delivered.
+// The channel is closed when unsubscribed or when the PubSub is closed.
+func (s *Subscription[T]) C() <-chan Message[T] {
+	return s.ch
+}
+
+// Unsubscribe stops the delivery of messages and closes the channel.
+// It is safe to call from the goroutine consuming the messages.
+func (s *Subscription[T]) Unsubscribe() {
+	s.ps.mu.Lock()
+	delete(s.ps.subs, s)
+	s.ps.mu.Unlock()
+
+	s.close()
+}
+
+// Deliver the message according to the policy.
+// Returns false if the message was dropped or the subscription was closed.
+func (s *Subscription[T]) send(ctx context.Context, msg Message[T]) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return false, nil
+	}
+
+	if s.policy != Block {
+		return deliver(ctx, s.ch, msg, s.policy), nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return false, context.Cause(ctx)
+	case <-s.done:
+		return false, nil
+	case s.ch <- msg:
+		return true, nil
+	}
+}
+
+// Close the channel once no publisher is sending to it.
+func (s *Subscription[T]) close() {
+	// Release any publisher blocked on this subscription before taking the lock
+	s.once.Do(func() { close(s.done) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/andrejacobs/go-aj/matches"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubSub(t *testing.T) {
+	ps := concurrency.NewPubSub[int]()
+	ctx := context.Background()
+
+	files, err := ps.Subscribe([]string{`^file\.`}, 10, concurrency.Block)
+	require.NoError(t, err)
+	all, err := ps.Subscribe([]string{`.*`}, 10, concurrency.Block)
+	require.NoError(t, err)
+	created, err := ps.Subscribe([]string{`\.created$`, `^dir\.`}, 10, concurrency.Block)
+	require.NoError(t, err)
+
+	publish := func(topic string, payload int, expected int) {
+		n, err := ps.Publish(ctx, topic, payload)
+		require.NoError(t, err)
+		assert.Equal(t, expected, n, topic)
+	}
+	publish("file.created", 1, 3)
+	publish("file.removed", 2, 2)
+	publish("dir.removed", 3, 2)
+	publish("other", 4, 1)
+
+	ps.Close()
+	_, err = ps.Publish(ctx, "file.created", 5)
+	assert.ErrorIs(t, err, concurrency.ErrPubSubClosed)
+	_, err = ps.Subscribe([]string{`.*`}, 0, concurrency.Block)
+	assert.ErrorIs(t, err, concurrency.ErrPubSubClosed)
+
+	assert.Equal(t, []concurrency.Message[int]{
+		{Topic: "file.created", Payload: 1},
+		{Topic: "file.removed", Payload: 2},
+	}, drain(files.C()))
+	assert.Len(t, drain(all.C()), 4)
+	assert.Equal(t, []concurrency.Message[int]{
+		{Topic: "file.created", Payload: 1},
+		{Topic: "dir.removed", Payload: 3},
+	}, drain(created.C()))
+
+	// Safe after close
+	files.Unsubscribe()
+}
+
+func TestPubSubInvalidExpression(t *testing.T) {
+	ps := concurrency.NewPubSub[int]()
+	_, err := ps.Subscribe([]string{`(`}, 0, concurrency.Block)
+	var compileErr *matches.RegexListCompileErr
+	assert.ErrorAs(t, err, &compileErr)
+}
+
+func TestPubSubUnsubscribeWhileBlocked(t *testing.T) {
+	ps := concurrency.NewPubSub[string]()
+	defer ps.Close()
+
+	sub, err := ps.Subscribe([]string{`.*`}, 0, concurrency.Block)
+	require.NoError(t, err)
+
+	result := make(chan int)
+	go func() {
+		n, err := ps.Publish(context.Background(), "topic", "never received")
+		assert.NoError(t, err)
+		result <- n
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	sub.Unsubscribe()
+	assert.Equal(t, 0, <-result)
+
+	_, ok := <-sub.C()
+	assert.False(t, ok)
+}
+
+func TestPubSubPolicies(t *testing.T) {
+	ps := concurrency.NewPubSub[int]()
+	latest, err := ps.Subscribe([]string{`^fast$`}, 1, concurrency.LatestOnly)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	blocked, err := ps.Subscribe([]string{`^slow$`}, 0, concurrency.Block)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err := ps.Publish(ctx, "fast", i)
+		require.NoError(t, err)
+	}
+	_, err = ps.Publish(ctx, "slow", 42)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ps.Close()
+	assert.Equal(t, []int{9}, payloads(drain(latest.C())))
+	assert.Empty(t, drain(blocked.C()))
+}
+
+func payloads[T any](msgs []concurrency.Message[T]) []T {
+	result := make([]T, len(msgs))
+	for i, m := range msgs {
+		result[i] = m.Payload
+	}
+	return result
+}
+
+func TestPubSubSlowSubscriberDoesNotStall(t *testing.T) {
+	ps := concurrency.NewPubSub[int]()
+
+	slow, err := ps.Subscribe([]string{`.*`}, 0, concurrency.Block)
+	require.NoError(t, err)
+
+	published := make(chan error, 1)
+	go func() {
+		_, err := ps.Publish(context.Background(), "topic", 1)
+		published <- err
+	}()
+
+	// Wait until the publisher is blocked on the slow subscriber
+	time.Sleep(10 * time.Millisecond)
+
+	stalled := make(chan struct{})
+	go func() {
+		defer close(stalled)
+		other, err := ps.Subscribe([]string{`.*`}, 1, concurrency.Block)
+		assert.NoError(t, err)
+		other.Unsubscribe()
+		ps.Close()
+	}()
+
+	select {
+	case <-stalled:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "blocked by the slow subscriber")
+	}
+
+	require.NoError(t, <-published)
+	_, ok := <-slow.C()
+	assert.False(t, ok)
+}
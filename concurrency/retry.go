@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffPolicy determines how long to wait between the attempts made by [Retry]
+// and which errors are worth retrying.
+type BackoffPolicy struct {
+	Initial    time.Duration        // delay before the second attempt
+	Max        time.Duration        // upper limit of the delay, 0 means no limit
+	Multiplier float64              // factor by which the delay grows after every attempt, values less than 1 mean 2
+	Jitter     float64              // fraction (0 to 1) of the delay that is randomized to spread out retries
+	Retryable  func(err error) bool // report whether the error should be retried, nil means all errors are retried
+}
+
+// DefaultBackoffPolicy starts at 100ms, doubles up to 10s and randomizes 20% of each delay.
+var DefaultBackoffPolicy = BackoffPolicy{
+	Initial:    100 * time.Millisecond,
+	Max:        10 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// Delay returns how long to wait after the specified (zero based) attempt failed.
+func (p BackoffPolicy) Delay(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(p.Initial)
+	for i := 0; i < attempt; i++ {
+		delay *= multiplier
+		if p.Max > 0 && delay >= float64(p.Max) {
+			delay = float64(p.Max)
+			break
+		}
+	}
+
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		// Spread the delay evenly within [delay - jitter%, delay + jitter%]
+		delay += delay * jitter * (2*rand.Float64() - 1) // #nosec G404 -- Not used for crypto
+	}
+
+	if p.Max > 0 && delay > float64(p.Max) {
+		delay = float64(p.Max)
+	}
+	if delay > float64(maxDuration) {
+		return maxDuration
+	}
+	return time.Duration(delay)
+}
+
+const maxDuration = time.Duration(1<<63 - 1)
+
+// Retry calls fn until it succeeds, returns an error that is not retryable according to the backoff policy
+// or the number of attempts have been made. The delay between attempts is determined by the backoff policy.
+// If attempts is less than 1 then fn is called once.
+// Returns the last error returned by fn, or the cause of the context cancellation while waiting to retry.
+func Retry(ctx context.Context, attempts int, backoff BackoffPolicy, fn func(ctx context.Context) error) error {
+	attempts = max(attempts, 1)
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+
+		if backoff.Retryable != nil && !backoff.Retryable(err) {
+			return err
+		}
+		if attempt == attempts-1 {
+			break
+		}
+
+		if sErr := Sleep(ctx, backoff.Delay(attempt)); sErr != nil {
+			return fmt.Errorf("failed to retry after attempt %d (%v). %w", attempt+1, err, sErr)
+		}
+	}
+
+	return fmt.Errorf("failed after %d attempts. %w", attempts, err)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fastBackoff = concurrency.BackoffPolicy{Initial: time.Millisecond, Max: 5 * time.Millisecond}
+
+func TestRetrySucceeds(t *testing.T) {
+	calls := 0
+	err := concurrency.Retry(context.Background(), 5, fastBackoff, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("flaky")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	calls := 0
+	err := concurrency.Retry(context.Background(), 4, fastBackoff, func(ctx context.Context) error {
+		calls++
+		return errFlaky
+	})
+	assert.ErrorIs(t, err, errFlaky)
+	assert.Equal(t, "failed after 4 attempts. flaky", err.Error())
+	assert.Equal(t, 4, calls)
+
+	// At least one attempt is made
+	calls = 0
+	err = concurrency.Retry(context.Background(), 0, fastBackoff, func(ctx context.Context) error {
+		calls++
+		return errFlaky
+	})
+	assert.ErrorIs(t, err, errFlaky)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	errFatal := errors.New("fatal")
+	policy := fastBackoff
+	policy.Retryable = func(err error) bool {
+		return !errors.Is(err, errFatal)
+	}
+
+	calls := 0
+	err := concurrency.Retry(context.Background(), 5, policy, func(ctx context.Context) error {
+		calls++
+		if calls == 2 {
+			return errFatal
+		}
+		return errors.New("flaky")
+	})
+	assert.Equal(t, errFatal, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := concurrency.BackoffPolicy{Initial: time.Hour}
+
+	errFlaky := errors.New("flaky")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	err := concurrency.Retry(ctx, 5, policy, func(ctx context.Context) error {
+		return errFlaky
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "flaky")
+}
+
+func TestBackoffPolicyDelay(t *testing.T) {
+	p := concurrency.BackoffPolicy{Initial: 100 * time.Millisecond, Max: time.Second}
+	assert.Equal(t, 100*time.Millisecond, p.Delay(0))
+	assert.Equal(t, 200*time.Millisecond, p.Delay(1))
+	assert.Equal(t, 800*time.Millisecond, p.Delay(3))
+	assert.Equal(t, time.Second, p.Delay(4))
+	assert.Equal(t, time.Second, p.Delay(1000))
+
+	p.Multiplier = 3
+	assert.Equal(t, 900*time.Millisecond, p.Delay(2))
+
+	p = concurrency.BackoffPolicy{Initial: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		d := p.Delay(0)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, 1500*time.Millisecond)
+	}
+
+	// No limit
+	p = concurrency.BackoffPolicy{Initial: time.Second}
+	assert.Equal(t, time.Duration(1<<63-1), p.Delay(1000))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemaphore(t *testing.T) {
+	sem := concurrency.NewSemaphore(10)
+	ctx := context.Background()
+
+	var inUse atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int64) {
+			defer wg.Done()
+			require.NoError(t, sem.Acquire(ctx, n))
+			assert.LessOrEqual(t, inUse.Add(n), int64(10))
+			time.Sleep(time.Millisecond)
+			inUse.Add(-n)
+			sem.Release(n)
+		}(int64(i%5 + 1))
+	}
+	wg.Wait()
+
+	// Everything was released
+	assert.True(t, sem.TryAcquire(10))
+	assert.False(t, sem.TryAcquire(1))
+	sem.Release(10)
+}
+
+func TestSemaphoreTooLarge(t *testing.T) {
+	sem := concurrency.NewSemaphore(2)
+	assert.ErrorIs(t, sem.Acquire(context.Background(), 3), concurrency.ErrWeightTooLarge)
+}
+
+func TestSemaphoreCanceled(t *testing.T) {
+	sem := concurrency.NewSemaphore(3)
+	require.True(t, sem.TryAcquire(2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, sem.Acquire(ctx, 2), context.DeadlineExceeded)
+
+	// The canceled waiter doesn't hold anything
+	require.True(t, sem.TryAcquire(1))
+	sem.Release(3)
+	assert.True(t, sem.TryAcquire(3))
+}
+
+func TestSemaphoreFIFO(t *testing.T) {
+	sem := concurrency.NewSemaphore(4)
+	ctx := context.Background()
+	require.NoError(t, sem.Acquire(ctx, 3))
+
+	// A large waiter blocks the smaller waiters that arrive later
+	large := make(chan struct{})
+	go func() {
+		require.NoError(t, sem.Acquire(ctx, 4))
+		close(large)
+	}()
+	require.Eventually(t, func() bool {
+		if sem.TryAcquire(1) {
+			sem.Release(1)
+			return false
+		}
+		return true
+	}, time.Second, time.Millisecond)
+
+	sem.Release(3)
+	<-large
+	sem.Release(4)
+}
+
+func TestSemaphoreReleasePanics(t *testing.T) {
+	sem := concurrency.NewSemaphore(1)
+	assert.Panics(t, func() { sem.Release(1) })
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"hash/maphash"
+	"sync"
+)
+
+// ShardedPool runs a work function over submitted items using a fixed number of workers, where every item
+// is routed to a worker by hashing its key. All the items with the same key are therefore processed
+// serially and in the order they were submitted, while items with different keys are processed in parallel.
+// Errors returned by the work function (and panics which are converted to a [PanicError])
+// are collected and returned by Wait.
+type ShardedPool[K comparable, T any] struct {
+	ctx    context.Context
+	fn     func(ctx context.Context, item T) error
+	key    func(item T) K
+	seed   maphash.Seed
+	shards []chan T
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+	errs   []error
+}
+
+// Create a new ShardedPool with the specified number of workers, each with a queue of queueSize items.
+// key is called to determine the key of an item and fn is called to process it.
+// If workers is less than 1 then a single worker is used.
+// The workers stop when the context is canceled.
+func NewShardedPool[K comparable, T any](ctx context.Context, workers int, queueSize int,
+	key func(item T) K, fn func(ctx context.Context, item T) error) *ShardedPool[K, T] {
+	workers = max(workers, 1)
+	p := &ShardedPool[K, T]{
+		ctx:    ctx,
+		fn:     fn,
+		key:    key,
+		seed:   maphash.MakeSeed(),
+		shards: make([]chan T, workers),
+	}
+
+	p.wg.Add(workers)
+	for i := range p.shards {
+		p.shards[i] = make(chan T, max(queueSize, 0))
+		go p.worker(p.shards[i])
+	}
+	return p
+}
+
+// Submit an item to be processed by the worker responsible for its key.
+// Blocks until the worker's queue has room or the context is canceled.
+// Returns [ErrPoolClosed] if Wait has already been called.
+func (p *ShardedPool[K, T]) Submit(item T) error {
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		return ErrPoolClosed
+	}
+
+	shard := p.shards[maphash.Comparable(p.seed, p.key(item))%uint64(len(p.shards))]
+	select {
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	case shard <- item:
+		return nil
+	}
+}
+
+// Wait stops accepting new items, waits for all the queued items to be processed and returns all the errors
+// that occurred joined using errors.Join. The context error is included if it was canceled.
+// Submit must not be called concurrently with or after Wait.
+func (p *ShardedPool[K, T]) Wait() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		for _, shard := range p.shards {
+			close(shard)
+		}
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	errs := p.errs
+	if err := p.ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (p *ShardedPool[K, T]) worker(shard <-chan T) {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case item, ok := <-shard:
+			if !ok {
+				return
+			}
+			if err := callRecover(func() error { return p.fn(p.ctx, item) }); err != nil {
+				p.mu.Lock()
+				p.errs = append(p.errs, err)
+				p.mu.Unlock()
+			}
+		}
+	}
+}
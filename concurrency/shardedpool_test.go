@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/andrejacobs/go-aj/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type event struct {
+	path string
+	seq  int
+}
+
+func TestShardedPool(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string][]int)
+	active := make(map[string]bool)
+
+	p := concurrency.NewShardedPool(context.Background(), 4, 10,
+		func(e event) string { return e.path },
+		func(ctx context.Context, e event) error {
+			mu.Lock()
+			assert.False(t, active[e.path], "items with the same key are processed concurrently")
+			active[e.path] = true
+			mu.Unlock()
+
+			time.Sleep(time.Duration(random.Int(0, 50)) * time.Microsecond)
+
+			mu.Lock()
+			active[e.path] = false
+			received[e.path] = append(received[e.path], e.seq)
+			mu.Unlock()
+			return nil
+		})
+
+	keys := 20
+	perKey := 50
+	for seq := 0; seq < perKey; seq++ {
+		for k := 0; k < keys; k++ {
+			require.NoError(t, p.Submit(event{path: fmt.Sprintf("file-%d", k), seq: seq}))
+		}
+	}
+	require.NoError(t, p.Wait())
+
+	require.Len(t, received, keys)
+	for path, seqs := range received {
+		require.Len(t, seqs, perKey, path)
+		for i, seq := range seqs {
+			assert.Equal(t, i, seq, path)
+		}
+	}
+
+	assert.ErrorIs(t, p.Submit(event{}), concurrency.ErrPoolClosed)
+}
+
+func TestShardedPoolErrors(t *testing.T) {
+	errBad := errors.New("bad")
+	p := concurrency.NewShardedPool(context.Background(), 2, 0,
+		func(v int) int { return v % 3 },
+		func(ctx context.Context, v int) error {
+			if v == 4 {
+				panic("four")
+			}
+			if v%3 == 0 {
+				return errBad
+			}
+			return nil
+		})
+
+	for i := 0; i < 9; i++ {
+		require.NoError(t, p.Submit(i))
+	}
+	err := p.Wait()
+	assert.ErrorIs(t, err, errBad)
+	var panicErr *concurrency.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 4)
+}
+
+func TestShardedPoolCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	p := concurrency.NewShardedPool(ctx, 1, 0,
+		func(v int) int { return v },
+		func(ctx context.Context, v int) error {
+			close(started)
+			<-ctx.Done()
+			return nil
+		})
+
+	require.NoError(t, p.Submit(1))
+	<-started
+	cancel()
+
+	assert.ErrorIs(t, p.Submit(2), context.Canceled)
+	assert.ErrorIs(t, p.Wait(), context.Canceled)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// A shutdown step did not return within the step timeout.
+var ErrShutdownTimeout = errors.New("concurrency: shutdown step timed out")
+
+// Shutdown coordinates the orderly teardown of components.
+// Components register a function to stop them as they are started and these are called
+// in the reverse order of registration when shutting down, so that a component is stopped
+// before the components it depends upon.
+type Shutdown struct {
+	stepTimeout time.Duration
+
+	mu    sync.Mutex
+	steps []shutdownStep
+	once  sync.Once
+	err   error
+}
+
+type shutdownStep struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Create a new Shutdown that allows each step up to stepTimeout to finish.
+// A stepTimeout of 0 means there is no limit.
+func NewShutdown(stepTimeout time.Duration) *Shutdown {
+	return &Shutdown{stepTimeout: stepTimeout}
+}
+
+// Register a function used to stop a component. The name is used to identify the step in errors.
+// The context passed to fn is canceled once the step timeout has elapsed.
+func (s *Shutdown) Register(name string, fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, shutdownStep{name: name, fn: fn})
+}
+
+// Run blocks until the context is done or one of the signals is received and then calls Stop.
+// If no signals are specified then only the context is used.
+// Returns the errors from Stop.
+func (s *Shutdown) Run(ctx context.Context, signals ...os.Signal) error {
+	if len(signals) > 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, signals...)
+		defer stop()
+	}
+
+	<-ctx.Done()
+	return s.Stop()
+}
+
+// Stop calls the registered functions in the reverse order of registration.
+// Every step is called even when a previous step failed or timed out. A step that does not return
+// within the step timeout is abandoned and reported as [ErrShutdownTimeout].
+// Returns all the errors joined using errors.Join. Calling Stop again returns the same result.
+func (s *Shutdown) Stop() error {
+	s.once.Do(func() {
+		s.mu.Lock()
+		steps := s.steps
+		s.mu.Unlock()
+
+		var errs []error
+		for i := len(steps) - 1; i >= 0; i-- {
+			if err := s.runStep(steps[i]); err != nil {
+				errs = append(errs, fmt.Errorf("failed to shut down %q. %w", steps[i].name, err))
+			}
+		}
+		s.err = errors.Join(errs...)
+	})
+	return s.err
+}
+
+func (s *Shutdown) runStep(step shutdownStep) error {
+	ctx := context.Background()
+	if s.stepTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, s.stepTimeout, ErrShutdownTimeout)
+		defer cancel()
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- callRecover(func() error { return step.fn(ctx) })
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownStop(t *testing.T) {
+	s := concurrency.NewShutdown(20 * time.Millisecond)
+	errFailed := errors.New("failed")
+
+	var mu sync.Mutex
+	var order []string
+	step := func(name string, err error) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return err
+		}
+	}
+
+	s.Register("database", step("database", nil))
+	s.Register("cache", step("cache", errFailed))
+	s.Register("stuck", func(ctx context.Context) error {
+		select {} // never returns
+	})
+	s.Register("panics", func(ctx context.Context) error {
+		panic("oops")
+	})
+	s.Register("server", step("server", nil))
+
+	err := s.Stop()
+	require.Error(t, err)
+	assert.Equal(t, []string{"server", "cache", "database"}, order)
+
+	assert.ErrorIs(t, err, errFailed)
+	assert.ErrorIs(t, err, concurrency.ErrShutdownTimeout)
+	var panicErr *concurrency.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Contains(t, err.Error(), `failed to shut down "cache". failed`)
+	assert.Contains(t, err.Error(), `failed to shut down "stuck"`)
+
+	// Only runs once
+	assert.Equal(t, err, s.Stop())
+	assert.Len(t, order, 3)
+}
+
+func TestShutdownRun(t *testing.T) {
+	s := concurrency.NewShutdown(0)
+	stopped := false
+	s.Register("component", func(ctx context.Context) error {
+		stopped = true
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+	require.NoError(t, s.Run(ctx))
+	assert.True(t, stopped)
+}
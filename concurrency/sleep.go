@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Sleep pauses the current goroutine for at least the duration d or until the context is done.
+// Returns the cause of the context cancellation if it was done before the duration elapsed.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
+
+// After returns a channel that is closed once the duration d has elapsed.
+// If the context is done first then the timer is stopped and the channel is never closed, thus it
+// should be used in a select along with ctx.Done(). Unlike time.After no resources are held once
+// either the duration elapsed or the context is done.
+func After(ctx context.Context, d time.Duration) <-chan struct{} {
+	ch := make(chan struct{})
+	if ctx.Err() != nil {
+		return ch
+	}
+
+	// The timer is only started once stopCtx has been assigned
+	var stopCtx func() bool
+	timer := time.AfterFunc(math.MaxInt64, func() {
+		stopCtx()
+		close(ch)
+	})
+	stopCtx = context.AfterFunc(ctx, func() {
+		timer.Stop()
+	})
+	timer.Reset(d)
+	if ctx.Err() != nil {
+		// Canceled before the timer was started
+		timer.Stop()
+	}
+
+	return ch
+}
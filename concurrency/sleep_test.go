@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSleep(t *testing.T) {
+	start := time.Now()
+	require.NoError(t, concurrency.Sleep(context.Background(), 10*time.Millisecond))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	require.NoError(t, concurrency.Sleep(context.Background(), 0))
+
+	errStop := errors.New("stop")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel(errStop)
+	}()
+	start = time.Now()
+	assert.ErrorIs(t, concurrency.Sleep(ctx, time.Hour), errStop)
+	assert.Less(t, time.Since(start), time.Minute)
+
+	// Already canceled
+	assert.ErrorIs(t, concurrency.Sleep(ctx, 0), errStop)
+}
+
+func TestAfter(t *testing.T) {
+	start := time.Now()
+	<-concurrency.After(context.Background(), 10*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := concurrency.After(ctx, 20*time.Millisecond)
+	cancel()
+
+	select {
+	case <-ch:
+		t.Fatal("expected the channel to not be closed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Already canceled
+	select {
+	case <-concurrency.After(ctx, 0):
+		t.Fatal("expected the channel to not be closed")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/andrejacobs/go-aj/ajio/vardata"
+)
+
+// Push was called after the queue was closed.
+var ErrQueueClosed = errors.New("concurrency: queue is closed")
+
+// SpillCodec converts the values stored in a [SpillQueue] to and from bytes when they are spilled to disk.
+// The data passed to Unmarshal is reused and must not be retained.
+type SpillCodec[T any] struct {
+	Marshal   func(v T) ([]byte, error)
+	Unmarshal func(data []byte) (T, error)
+}
+
+// SpillQueue is an unbounded FIFO queue that can be shared by producer and consumer goroutines.
+// Up to a limit of values are kept in memory after which the overflow is spilled to a temporary file
+// using [vardata.VariableData] framing. The order of values is always preserved.
+// Push never blocks on the consumer, meaning a huge burst from the producer is absorbed by the disk.
+type SpillQueue[T any] struct {
+	dir      string
+	memLimit int
+	codec    SpillCodec[T]
+	vd       vardata.VariableData
+
+	mu     sync.Mutex
+	mem    []T // values to be popped before any of the spilled values
+	closed bool
+	notify chan struct{}
+
+	file     *os.File
+	writer   *bufio.Writer
+	writeOff int64 // offset at which the next spilled value will be written
+	readOff  int64 // offset of the next spilled value to be read
+	spilled  int   // number of values in the file that have not been read
+	buffer   []byte
+}
+
+// Create a new SpillQueue that keeps up to memLimit values in memory and spills the rest to a temporary
+// file created in dir (or the default directory for temporary files when empty).
+// If memLimit is less than 1 then a limit of 1 is used.
+func NewSpillQueue[T any](dir string, memLimit int, codec SpillCodec[T]) *SpillQueue[T] {
+	return &SpillQueue[T]{
+		dir:      dir,
+		memLimit: max(memLimit, 1),
+		codec:    codec,
+		vd:       vardata.NewVariableData(),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Push a value to the back of the queue.
+// Returns [ErrQueueClosed] if Close has been called.
+func (q *SpillQueue[T]) Push(v T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	if q.spilled == 0 && len(q.mem) < q.memLimit {
+		q.mem = append(q.mem, v)
+	} else if err := q.spill(v); err != nil {
+		return err
+	}
+
+	q.signal()
+	return nil
+}
+
+// Pop removes the value at the front of the queue, blocking until one is available or the context is done.
+// The returned bool is false when the queue has been closed and all the values have been popped.
+// Returns the cause of the context cancellation if no value was popped.
+func (q *SpillQueue[T]) Pop(ctx context.Context) (T, bool, error) {
+	var zero T
+	for {
+		if ctx.Err() != nil {
+			return zero, false, context.Cause(ctx)
+		}
+
+		q.mu.Lock()
+		if len(q.mem) == 0 && q.spilled > 0 {
+			if err := q.unspill(); err != nil {
+				q.mu.Unlock()
+				return zero, false, err
+			}
+		}
+
+		if len(q.mem) > 0 {
+			v := q.mem[0]
+			q.mem[0] = zero
+			q.mem = q.mem[1:]
+			if q.len() > 0 {
+				// Wake up other consumers that might have missed the signal
+				q.signal()
+			}
+			q.mu.Unlock()
+			return v, true, nil
+		}
+
+		if q.closed {
+			err := q.removeFile()
+			q.mu.Unlock()
+			return zero, false, err
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return zero, false, context.Cause(ctx)
+		case <-q.notify:
+		}
+	}
+}
+
+// Len returns the number of values in the queue (both in memory and spilled to disk).
+func (q *SpillQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.len()
+}
+
+// Close the queue so that no more values can be pushed.
+// The values already in the queue can still be popped, after which Pop reports the queue is closed
+// and the temporary file is removed.
+func (q *SpillQueue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.signal()
+	if q.spilled == 0 {
+		return q.removeFile()
+	}
+	return nil
+}
+
+// Discard closes the queue, drops all the values and removes the temporary file.
+func (q *SpillQueue[T]) Discard() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.mem = nil
+	q.spilled = 0
+	q.signal()
+	return q.removeFile()
+}
+
+func (q *SpillQueue[T]) len() int {
+	return len(q.mem) + q.spilled
+}
+
+func (q *SpillQueue[T]) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Write the value to the end of the temporary file.
+func (q *SpillQueue[T]) spill(v T) error {
+	if q.file == nil {
+		f, err := os.CreateTemp(q.dir, "spillqueue-*")
+		if err != nil {
+			return fmt.Errorf("failed to create the spill file. %w", err)
+		}
+		q.file = f
+		q.writer = bufio.NewWriter(io.NewOffsetWriter(f, 0))
+	}
+
+	data, err := q.codec.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the value to be spilled. %w", err)
+	}
+
+	n, err := q.vd.Write(q.writer, data)
+	if err != nil {
+		return fmt.Errorf("failed to write to the spill file %q. %w", q.file.Name(), err)
+	}
+
+	q.writeOff += int64(n)
+	q.spilled++
+	return nil
+}
+
+// Read up to the memory limit of values from the temporary file.
+func (q *SpillQueue[T]) unspill() error {
+	if err := q.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write to the spill file %q. %w", q.file.Name(), err)
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(q.file, q.readOff, q.writeOff-q.readOff))
+	for q.spilled > 0 && len(q.mem) < q.memLimit {
+		data, n, err := q.vd.Read(r, q.buffer)
+		if err != nil {
+			return fmt.Errorf("failed to read from the spill file %q. %w", q.file.Name(), err)
+		}
+		q.buffer = data
+		q.readOff += int64(n)
+		q.spilled--
+
+		v, err := q.codec.Unmarshal(data)
+		if err != nil {
+			// The value is dropped so that the next Pop can continue
+			return fmt.Errorf("failed to unmarshal the spilled value. %w", err)
+		}
+		q.mem = append(q.mem, v)
+	}
+
+	if q.spilled == 0 {
+		// Start writing from the beginning again
+		q.readOff = 0
+		q.writeOff = 0
+		q.writer.Reset(io.NewOffsetWriter(q.file, 0))
+		if err := q.file.Truncate(0); err != nil {
+			return fmt.Errorf("failed to truncate the spill file %q. %w", q.file.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (q *SpillQueue[T]) removeFile() error {
+	if q.file == nil {
+		return nil
+	}
+
+	name := q.file.Name()
+	cErr := q.file.Close()
+	q.file = nil
+	q.writer = nil
+	q.readOff = 0
+	q.writeOff = 0
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("failed to remove the spill file %q. %w", name, err)
+	}
+	return cErr
+}
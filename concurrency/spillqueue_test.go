@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var intCodec = concurrency.SpillCodec[int]{
+	Marshal: func(v int) ([]byte, error) {
+		return binary.AppendVarint(nil, int64(v)), nil
+	},
+	Unmarshal: func(data []byte) (int, error) {
+		v, _ := binary.Varint(data)
+		return int(v), nil
+	},
+}
+
+func TestSpillQueue(t *testing.T) {
+	dir := t.TempDir()
+	q := concurrency.NewSpillQueue(dir, 10, intCodec)
+	ctx := context.Background()
+
+	// Burst much larger than the memory limit
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, q.Push(i))
+	}
+	assert.Equal(t, 1000, q.Len())
+	assertSpillFiles(t, dir, 1)
+
+	// Interleave pops and pushes to check the order is preserved
+	next := 0
+	for i := 0; i < 500; i++ {
+		v, ok, err := q.Pop(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, next, v)
+		next++
+	}
+	for i := 1000; i < 1500; i++ {
+		require.NoError(t, q.Push(i))
+	}
+
+	require.NoError(t, q.Close())
+	assert.ErrorIs(t, q.Push(1), concurrency.ErrQueueClosed)
+
+	for {
+		v, ok, err := q.Pop(ctx)
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		require.Equal(t, next, v)
+		next++
+	}
+	assert.Equal(t, 1500, next)
+	assert.Equal(t, 0, q.Len())
+
+	// The temporary file is removed once drained
+	assertSpillFiles(t, dir, 0)
+}
+
+func TestSpillQueueConcurrent(t *testing.T) {
+	q := concurrency.NewSpillQueue(t.TempDir(), 5, intCodec)
+	ctx := context.Background()
+	expectedCount := 10000
+
+	go func() {
+		for i := 0; i < expectedCount; i++ {
+			assert.NoError(t, q.Push(i))
+		}
+		assert.NoError(t, q.Close())
+	}()
+
+	next := 0
+	for {
+		v, ok, err := q.Pop(ctx)
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		require.Equal(t, next, v)
+		next++
+	}
+	assert.Equal(t, expectedCount, next)
+}
+
+func TestSpillQueuePopCanceled(t *testing.T) {
+	q := concurrency.NewSpillQueue(t.TempDir(), 5, intCodec)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, ok, err := q.Pop(ctx)
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSpillQueueDiscard(t *testing.T) {
+	dir := t.TempDir()
+	q := concurrency.NewSpillQueue(dir, 1, intCodec)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, q.Push(i))
+	}
+	assertSpillFiles(t, dir, 1)
+
+	require.NoError(t, q.Discard())
+	assertSpillFiles(t, dir, 0)
+	assert.Equal(t, 0, q.Len())
+
+	_, ok, err := q.Pop(context.Background())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSpillQueueCodecErrors(t *testing.T) {
+	errBad := errors.New("bad")
+	codec := concurrency.SpillCodec[string]{
+		Marshal: func(v string) ([]byte, error) {
+			if v == "bad" {
+				return nil, errBad
+			}
+			return []byte(v), nil
+		},
+		Unmarshal: func(data []byte) (string, error) {
+			if _, err := strconv.Atoi(string(data)); err != nil {
+				return "", err
+			}
+			return string(data), nil
+		},
+	}
+
+	q := concurrency.NewSpillQueue(t.TempDir(), 1, codec)
+	defer q.Discard()
+
+	require.NoError(t, q.Push("1"))
+	assert.ErrorIs(t, q.Push("bad"), errBad)
+	require.NoError(t, q.Push("x"))
+
+	v, ok, err := q.Pop(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "1", v)
+
+	_, _, err = q.Pop(context.Background())
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	// The bad value was dropped
+	require.NoError(t, q.Push("2"))
+	v, ok, err = q.Pop(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "2", v)
+}
+
+func assertSpillFiles(t *testing.T, dir string, expected int) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, expected)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// WaitGroupCtx waits for a collection of functions to finish, like sync.WaitGroup, except that
+// waiting can be abandoned when a context is done and the errors returned by the functions
+// (and panics which are converted to a [PanicError]) are collected.
+// The zero value is ready to use.
+type WaitGroupCtx struct {
+	mu    sync.Mutex
+	count int
+	done  chan struct{} // closed when count drops to 0
+	errs  []error
+}
+
+// Go calls fn in a new goroutine.
+func (wg *WaitGroupCtx) Go(fn func() error) {
+	wg.mu.Lock()
+	if wg.count == 0 {
+		wg.done = make(chan struct{})
+	}
+	wg.count++
+	wg.mu.Unlock()
+
+	go func() {
+		err := callRecover(fn)
+
+		wg.mu.Lock()
+		defer wg.mu.Unlock()
+		if err != nil {
+			wg.errs = append(wg.errs, err)
+		}
+		wg.count--
+		if wg.count == 0 {
+			close(wg.done)
+		}
+	}()
+}
+
+// Wait blocks until all the functions have returned or the context is done.
+// Returns all the errors returned so far joined using errors.Join, or the cause of
+// the context cancellation if it was done before all the functions returned.
+func (wg *WaitGroupCtx) Wait(ctx context.Context) error {
+	wg.mu.Lock()
+	if wg.count == 0 {
+		defer wg.mu.Unlock()
+		return errors.Join(wg.errs...)
+	}
+	done := wg.done
+	wg.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-done:
+		wg.mu.Lock()
+		defer wg.mu.Unlock()
+		return errors.Join(wg.errs...)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitGroupCtx(t *testing.T) {
+	var wg concurrency.WaitGroupCtx
+	require.NoError(t, wg.Wait(context.Background()))
+
+	var count atomic.Int32
+	for i := 0; i < 100; i++ {
+		wg.Go(func() error {
+			count.Add(1)
+			return nil
+		})
+	}
+	require.NoError(t, wg.Wait(context.Background()))
+	assert.Equal(t, int32(100), count.Load())
+}
+
+func TestWaitGroupCtxErrors(t *testing.T) {
+	var wg concurrency.WaitGroupCtx
+	errBad := errors.New("bad")
+
+	wg.Go(func() error { return errBad })
+	wg.Go(func() error { panic("oops") })
+	wg.Go(func() error { return nil })
+
+	err := wg.Wait(context.Background())
+	assert.ErrorIs(t, err, errBad)
+	var panicErr *concurrency.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+}
+
+func TestWaitGroupCtxCanceled(t *testing.T) {
+	var wg concurrency.WaitGroupCtx
+	release := make(chan struct{})
+	wg.Go(func() error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, wg.Wait(ctx), context.DeadlineExceeded)
+
+	// Can wait again once the function returns
+	close(release)
+	require.NoError(t, wg.Wait(context.Background()))
+}
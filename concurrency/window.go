@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency
+
+import (
+	"context"
+)
+
+// Window consumes from the 'in' channel and produces sliding windows of size values, where each new window
+// starts step values after the previous window. For example a size of 3 and step of 1 over the values
+// 1, 2, 3, 4 produces [1 2 3] and [2 3 4]. A step larger than size skips values between windows.
+// Only full windows are produced and each window is a new slice that the consumer may keep.
+// If size or step is less than 1 then 1 is used.
+// The returned channel is closed once the 'in' channel is closed or the context is canceled.
+func Window[T any](ctx context.Context, in <-chan T, size int, step int) <-chan []T {
+	size = max(size, 1)
+	step = max(step, 1)
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+
+		window := make([]T, 0, size)
+		skip := 0
+		for {
+			data, ok, err := Recv(ctx, in)
+			if err != nil || !ok {
+				return
+			}
+
+			if skip > 0 {
+				skip--
+				continue
+			}
+
+			window = append(window, data)
+			if len(window) < size {
+				continue
+			}
+
+			if Send(ctx, out, append([]T(nil), window...)) != nil {
+				return
+			}
+
+			if step >= size {
+				window = window[:0]
+				skip = step - size
+			} else {
+				n := copy(window, window[step:])
+				clear(window[n:])
+				window = window[:n]
+			}
+		}
+	}()
+
+	return out
+}
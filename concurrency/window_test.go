@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+	"github.com/stretchr/testify/assert"
+)
+
+func produce(count int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		for i := 1; i <= count; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+func TestWindow(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+		drain(concurrency.Window(ctx, produce(5), 3, 1)))
+
+	assert.Equal(t, [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6, 7}},
+		drain(concurrency.Window(ctx, produce(8), 3, 2)))
+
+	// Tumbling
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5, 6}},
+		drain(concurrency.Window(ctx, produce(7), 2, 2)))
+
+	// Skipping
+	assert.Equal(t, [][]int{{1, 2}, {6, 7}},
+		drain(concurrency.Window(ctx, produce(9), 2, 5)))
+
+	// Not enough for a full window
+	assert.Empty(t, drain(concurrency.Window(ctx, produce(2), 3, 1)))
+
+	// Defaults
+	assert.Equal(t, [][]int{{1}, {2}}, drain(concurrency.Window(ctx, produce(2), 0, 0)))
+}
+
+func TestWindowCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := concurrency.Window(ctx, in, 2, 1)
+	cancel()
+
+	_, ok := <-out
+	assert.False(t, ok)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// DefaultByteCheckInterval is the default number of single byte operations performed between
+// checking the context state.
+const DefaultByteCheckInterval = 1024
+
+// ByteReader is able to read a slice of bytes as well as a single byte.
+// It matches the vardata.Reader interface.
+type ByteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// ByteWriter is able to write a slice of bytes as well as a single byte.
+type ByteWriter interface {
+	io.Writer
+	io.ByteWriter
+}
+
+// NewByteReader wraps an [io.Reader] to handle context cancellation while also implementing [io.ByteReader].
+//
+// Context state is checked BEFORE every Read and before every N calls to ReadByte to keep the overhead low.
+// N defaults to [DefaultByteCheckInterval] and can be changed using [WithByteCheckInterval].
+// If r does not implement io.ByteReader then reads are buffered.
+func NewByteReader(ctx context.Context, r io.Reader, opts ...Option) ByteReader {
+	o := applyOptions(opts)
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		buffered := bufio.NewReader(r)
+		r, br = buffered, buffered
+	}
+
+	return &byteReader{
+		reader:   newReader(ctx, r, o),
+		br:       br,
+		interval: byteCheckInterval(o),
+	}
+}
+
+// NewByteWriter wraps an [io.Writer] to handle context cancellation while also implementing [io.ByteWriter].
+//
+// Context state is checked BEFORE every Write and before every N calls to WriteByte to keep the overhead low.
+// N defaults to [DefaultByteCheckInterval] and can be changed using [WithByteCheckInterval].
+// If w does not implement io.ByteWriter then every WriteByte results in a Write of a single byte.
+func NewByteWriter(ctx context.Context, w io.Writer, opts ...Option) ByteWriter {
+	o := applyOptions(opts)
+	bw, ok := w.(io.ByteWriter)
+	if !ok {
+		bw = singleByteWriter{w: w}
+	}
+
+	return &byteWriter{
+		writer:   newWriter(ctx, w, o),
+		bw:       bw,
+		interval: byteCheckInterval(o),
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+type byteReader struct {
+	reader
+	br       io.ByteReader
+	interval int
+	count    int
+}
+
+// ReadByte implements [io.ByteReader], but with context awareness.
+func (r *byteReader) ReadByte() (byte, error) {
+	r.count++
+	if r.count >= r.interval {
+		r.count = 0
+		if err := r.stall.err(r.ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	r.stall.begin()
+	b, err := r.br.ReadByte()
+	if err == nil {
+		r.stall.end(1)
+	} else {
+		r.stall.end(0)
+	}
+	return b, err
+}
+
+type byteWriter struct {
+	writer
+	bw       io.ByteWriter
+	interval int
+	count    int
+}
+
+// WriteByte implements [io.ByteWriter], but with context awareness.
+func (w *byteWriter) WriteByte(c byte) error {
+	w.count++
+	if w.count >= w.interval {
+		w.count = 0
+		if err := w.stall.err(w.ctx); err != nil {
+			return err
+		}
+	}
+
+	w.stall.begin()
+	err := w.bw.WriteByte(c)
+	if err == nil {
+		w.stall.end(1)
+	} else {
+		w.stall.end(0)
+	}
+	return err
+}
+
+type singleByteWriter struct {
+	w io.Writer
+}
+
+func (s singleByteWriter) WriteByte(c byte) error {
+	_, err := s.w.Write([]byte{c})
+	return err
+}
+
+func byteCheckInterval(o options) int {
+	if o.byteCheckInterval > 0 {
+		return o.byteCheckInterval
+	}
+	return DefaultByteCheckInterval
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/ajio/vardata"
+	"github.com/andrejacobs/go-aj/file/contextio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// LimitReader does not implement io.ByteReader
+	r := contextio.NewByteReader(ctx, io.LimitReader(bytes.NewReader([]byte("abcdef")), 6),
+		contextio.WithByteCheckInterval(2))
+
+	b, err := r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte('a'), b)
+
+	cancel()
+
+	// The context is only checked every 2nd byte
+	b, err = r.ReadByte()
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, byte(0), b)
+
+	b, err = r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte('b'), b)
+
+	_, err = r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestByteWriter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var buf bytes.Buffer
+	w := contextio.NewByteWriter(ctx, &buf, contextio.WithByteCheckInterval(2))
+
+	require.NoError(t, w.WriteByte('a'))
+	_, err := w.Write([]byte("bc"))
+	require.NoError(t, err)
+
+	cancel()
+	assert.ErrorIs(t, w.WriteByte('d'), context.Canceled)
+	require.NoError(t, w.WriteByte('e'))
+	_, err = w.Write([]byte("f"))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, "abce", buf.String())
+}
+
+func TestByteReaderWithVarData(t *testing.T) {
+	vd := vardata.NewVariableData()
+
+	var buf bytes.Buffer
+	w := contextio.NewByteWriter(context.Background(), &buf)
+	_, err := vd.Write(w, []byte("hello world"))
+	require.NoError(t, err)
+
+	r := contextio.NewByteReader(context.Background(), &buf)
+	data, _, err := vd.Read(r, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+}
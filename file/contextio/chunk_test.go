@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/file/contextio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cancelAfterWriter struct {
+	buf    bytes.Buffer
+	calls  int
+	after  int
+	cancel context.CancelFunc
+}
+
+func (w *cancelAfterWriter) Write(p []byte) (int, error) {
+	w.calls++
+	if w.calls == w.after {
+		w.cancel()
+	}
+	return w.buf.Write(p)
+}
+
+func TestWriterWithChunkSize(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10)
+
+	var buf bytes.Buffer
+	w := contextio.NewWriter(context.Background(), &buf, contextio.WithChunkSize(16))
+	n, err := w.Write(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, data, buf.Bytes())
+}
+
+func TestWriterWithChunkSizeCanceled(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cw := &cancelAfterWriter{after: 2, cancel: cancel}
+	w := contextio.NewWriter(ctx, cw, contextio.WithChunkSize(16))
+
+	n, err := w.Write(data)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 32, n)
+	assert.Equal(t, 2, cw.calls)
+	assert.Equal(t, data[:32], cw.buf.Bytes())
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// NewReadCloser wraps an [io.ReadCloser] to handle context cancellation.
+//
+// Context state is checked BEFORE every Read. Close is passed through to the underlying io.ReadCloser.
+// When [WithCloseOnCancel] is used, the io.ReadCloser is closed as soon as the context is canceled
+// which unblocks a Read that is waiting for data.
+func NewReadCloser(ctx context.Context, rc io.ReadCloser, opts ...Option) io.ReadCloser {
+	o := applyOptions(opts)
+	rd := newReader(ctx, rc, o)
+	r := &readCloser{
+		reader:       rd,
+		cancelCloser: newCancelCloser(rd.ctx, rc, rd.stall, o),
+	}
+	return r
+}
+
+// NewWriteCloser wraps an [io.WriteCloser] to handle context cancellation.
+//
+// Context state is checked BEFORE every Write. Close is passed through to the underlying io.WriteCloser.
+// When [WithCloseOnCancel] is used, the io.WriteCloser is closed as soon as the context is canceled
+// which unblocks a Write that is waiting.
+func NewWriteCloser(ctx context.Context, wc io.WriteCloser, opts ...Option) io.WriteCloser {
+	o := applyOptions(opts)
+	wr := newWriter(ctx, wc, o)
+	w := &writeCloser{
+		writer:       wr,
+		cancelCloser: newCancelCloser(wr.ctx, wc, wr.stall, o),
+	}
+	return w
+}
+
+//-----------------------------------------------------------------------------
+
+type readCloser struct {
+	reader
+	*cancelCloser
+}
+
+func (r *readCloser) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	return n, r.ctxErr(err)
+}
+
+type writeCloser struct {
+	writer
+	*cancelCloser
+}
+
+func (w *writeCloser) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	return n, w.ctxErr(err)
+}
+
+//-----------------------------------------------------------------------------
+
+// cancelCloser closes the underlying io.Closer exactly once, either when Close is called
+// or optionally when the context is canceled or an operation stalled.
+type cancelCloser struct {
+	ctx   context.Context
+	c     io.Closer
+	stop  func()
+	stall *stallWatchdog
+
+	once sync.Once
+	err  error
+}
+
+func newCancelCloser(ctx context.Context, c io.Closer, stall *stallWatchdog, o options) *cancelCloser {
+	cc := &cancelCloser{ctx: ctx, c: c, stall: stall}
+	if o.closeOnCancel {
+		closeFn := func() {
+			_ = cc.close()
+		}
+		stopCtx := context.AfterFunc(ctx, closeFn)
+		stopStall := stall.afterStall(closeFn)
+		cc.stop = func() {
+			stopCtx()
+			stopStall()
+		}
+	}
+	return cc
+}
+
+// Close implements [io.Closer].
+// Calling Close more than once returns the error from the first call.
+func (cc *cancelCloser) Close() error {
+	if cc.stop != nil {
+		cc.stop()
+	}
+	cc.stall.stop()
+	return cc.close()
+}
+
+func (cc *cancelCloser) close() error {
+	cc.once.Do(func() {
+		cc.err = cc.c.Close()
+	})
+	return cc.err
+}
+
+// Report the context error instead of the error caused by closing the resource on cancellation.
+func (cc *cancelCloser) ctxErr(err error) error {
+	if err != nil && err != io.EOF && cc.stop != nil {
+		if ctxErr := cc.stall.err(cc.ctx); ctxErr != nil {
+			return ctxErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/file/contextio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCloser(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("hello"))
+		pw.Close()
+	}()
+
+	r := contextio.NewReadCloser(context.Background(), pr)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	require.NoError(t, r.Close())
+	require.NoError(t, r.Close())
+}
+
+func TestReadCloserCanceledBeforeRead(t *testing.T) {
+	pr, _ := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := contextio.NewReadCloser(ctx, pr)
+	_, err := r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, r.Close())
+}
+
+func TestReadCloserCloseOnCancel(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := contextio.NewReadCloser(ctx, pr, contextio.WithCloseOnCancel())
+
+	errCh := make(chan error, 1)
+	go func() {
+		// Blocks until the pipe is closed
+		_, err := r.Read(make([]byte, 1))
+		errCh <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the blocked Read to return")
+	}
+
+	require.NoError(t, r.Close())
+}
+
+func TestWriteCloserCloseOnCancel(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := contextio.NewWriteCloser(ctx, pw, contextio.WithCloseOnCancel())
+
+	errCh := make(chan error, 1)
+	go func() {
+		// Blocks until the pipe is read from or closed
+		_, err := w.Write([]byte("hello"))
+		errCh <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the blocked Write to return")
+	}
+
+	require.NoError(t, w.Close())
+}
@@ -24,8 +24,10 @@ import (
 )
 
 type writer struct {
-	ctx context.Context
-	w   io.Writer
+	ctx   context.Context
+	w     io.Writer
+	opts  options
+	stall *stallWatchdog
 }
 
 type copier struct {
@@ -38,58 +40,125 @@ type copier struct {
 //
 // The returned Writer also implements [io.ReaderFrom] to allow [io.Copy] to select
 // the best strategy while still checking the context state before every chunk transfer.
-func NewWriter(ctx context.Context, w io.Writer) io.Writer {
-	if w, ok := w.(*copier); ok && ctx == w.ctx {
+//
+// AJ: Options such as [WithTimeout] and [WithStallTimeout] can be used to further configure the behaviour.
+func NewWriter(ctx context.Context, w io.Writer, opts ...Option) io.Writer {
+	o := applyOptions(opts)
+	if w, ok := w.(*copier); ok && ctx == w.ctx && o == w.opts {
 		return w
 	}
-	return &copier{writer{ctx: ctx, w: w}}
+	return &copier{newWriter(ctx, w, o)}
+}
+
+func newWriter(ctx context.Context, w io.Writer, o options) writer {
+	return writer{ctx: ctx, w: w, opts: o, stall: newStallWatchdog(o.stallTimeout)}
+}
+
+// Returns true if the Write needs to be able to return before the underlying Write has completed.
+func (w *writer) interruptible() bool {
+	return w.opts.timeout > 0 || w.stall != nil
 }
 
 // Write implements [io.Writer], but with context awareness.
+//
+// AJ: When [WithChunkSize] is used, large writes are split into chunks and the context is
+// checked before every chunk.
 func (w *writer) Write(p []byte) (n int, err error) {
-	select {
-	case <-w.ctx.Done():
-		return 0, w.ctx.Err()
-	default:
-		return w.w.Write(p)
+	chunkSize := w.opts.chunkSize
+	if chunkSize <= 0 || len(p) <= chunkSize {
+		return w.write(p)
 	}
+
+	for len(p) > 0 {
+		chunk := p[:min(chunkSize, len(p))]
+		written, err := w.write(chunk)
+		n += written
+		if err != nil {
+			return n, err
+		}
+		if written < len(chunk) {
+			return n, io.ErrShortWrite
+		}
+		p = p[written:]
+	}
+	return n, nil
+}
+
+func (w *writer) write(p []byte) (n int, err error) {
+	if err := w.stall.err(w.ctx); err != nil {
+		return 0, err
+	}
+
+	ctx, done := w.stall.watch(w.ctx)
+	defer done()
+	w.stall.begin()
+	if w.interruptible() {
+		n, err = writeWithTimeout(ctx, w.opts.timeout, w.w, p)
+	} else {
+		n, err = w.w.Write(p)
+	}
+	w.stall.end(n)
+	return n, err
 }
 
 type reader struct {
-	ctx context.Context
-	r   io.Reader
+	ctx   context.Context
+	r     io.Reader
+	opts  options
+	stall *stallWatchdog
 }
 
 // NewReader wraps an [io.Reader] to handle context cancellation.
 //
 // Context state is checked BEFORE every Read.
-func NewReader(ctx context.Context, r io.Reader) io.Reader {
-	if r, ok := r.(*reader); ok && ctx == r.ctx {
+//
+// AJ: Options such as [WithTimeout] and [WithStallTimeout] can be used to further configure the behaviour.
+func NewReader(ctx context.Context, r io.Reader, opts ...Option) io.Reader {
+	o := applyOptions(opts)
+	if r, ok := r.(*reader); ok && ctx == r.ctx && o == r.opts {
 		return r
 	}
-	return &reader{ctx: ctx, r: r}
+	rd := newReader(ctx, r, o)
+	return &rd
+}
+
+func newReader(ctx context.Context, r io.Reader, o options) reader {
+	return reader{ctx: ctx, r: r, opts: o, stall: newStallWatchdog(o.stallTimeout)}
+}
+
+// Returns true if the Read needs to be able to return before the underlying Read has completed.
+func (r *reader) interruptible() bool {
+	return r.opts.timeout > 0 || r.stall != nil
 }
 
 func (r *reader) Read(p []byte) (n int, err error) {
-	select {
-	case <-r.ctx.Done():
-		return 0, r.ctx.Err()
-	default:
-		return r.r.Read(p)
+	if err := r.stall.err(r.ctx); err != nil {
+		return 0, err
+	}
+
+	ctx, done := r.stall.watch(r.ctx)
+	defer done()
+	r.stall.begin()
+	if r.interruptible() {
+		n, err = readWithTimeout(ctx, r.opts.timeout, r.r, p)
+	} else {
+		n, err = r.r.Read(p)
 	}
+	r.stall.end(n)
+	return n, err
 }
 
 // ReadFrom implements interface [io.ReaderFrom], but with context awareness.
 //
 // This should allow efficient copying allowing writer or reader to define the chunk size.
 func (w *copier) ReadFrom(r io.Reader) (n int64, err error) {
-	if _, ok := w.w.(io.ReaderFrom); ok {
+	if _, ok := w.w.(io.ReaderFrom); ok && !w.interruptible() {
 		// Let the original Writer decide the chunk size.
 		return io.Copy(w.w, &reader{ctx: w.ctx, r: r})
 	}
 	select {
 	case <-w.ctx.Done():
-		return 0, w.ctx.Err()
+		return 0, context.Cause(w.ctx)
 	default:
 		// The original Writer is not a ReaderFrom.
 		// Let the Reader decide the chunk size.
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio
+
+import "time"
+
+// Option is used to configure the optional behaviour of the wrappers.
+type Option func(o *options)
+
+type options struct {
+	closeOnCancel bool
+	timeout       time.Duration
+	stallTimeout  time.Duration
+	chunkSize     int
+
+	byteCheckInterval int
+}
+
+// Close the underlying resource as soon as the context is canceled or an operation stalled (see [WithStallTimeout]).
+// This unblocks a Read or Write that is stuck waiting on for example a pipe or socket.
+// Only applies to wrappers that own an io.Closer.
+func WithCloseOnCancel() Option {
+	return func(o *options) {
+		o.closeOnCancel = true
+	}
+}
+
+// Apply a timeout to each individual Read or Write, independent of the deadline of the parent context.
+// A [*TimeoutError] is returned when an operation does not complete within the timeout.
+//
+// NOTE: The underlying Read or Write can not be interrupted and thus continues to run in the
+// background after a timeout. Any data it produces afterwards is discarded. Combine with
+// [WithCloseOnCancel] or close the resource to release it.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// Cancel with [ErrStalled] when a Read or Write has not transferred any bytes for the specified duration.
+// The timer only runs while an operation is in progress, time spent between operations is not counted.
+// A Read or Write that is blocked at the time is abandoned, see the note on [WithTimeout].
+// A ReadByte or WriteByte can't be abandoned and the stall is reported by the next context check instead,
+// combine with [WithCloseOnCancel] to close the resource as soon as an operation stalls.
+func WithStallTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.stallTimeout = d
+	}
+}
+
+// Split a Write that is larger than size bytes into chunks of at most size bytes and check
+// the context before every chunk. This allows a very large Write to be interrupted part way through,
+// in which case the number of bytes written so far is returned along with the error.
+func WithChunkSize(size int) Option {
+	return func(o *options) {
+		o.chunkSize = size
+	}
+}
+
+// Check the context state every n single byte operations performed by [NewByteReader] and [NewByteWriter].
+func WithByteCheckInterval(n int) Option {
+	return func(o *options) {
+		o.byteCheckInterval = n
+	}
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// NewReaderAt wraps an [io.ReaderAt] to handle context cancellation.
+//
+// Context state is checked BEFORE every ReadAt.
+func NewReaderAt(ctx context.Context, ra io.ReaderAt, opts ...Option) io.ReaderAt {
+	o := applyOptions(opts)
+	return &readerAt{ctx: ctx, ra: ra, timeout: o.timeout, stall: newStallWatchdog(o.stallTimeout)}
+}
+
+// NewWriterAt wraps an [io.WriterAt] to handle context cancellation.
+//
+// Context state is checked BEFORE every WriteAt.
+func NewWriterAt(ctx context.Context, wa io.WriterAt, opts ...Option) io.WriterAt {
+	o := applyOptions(opts)
+	return &writerAt{ctx: ctx, wa: wa, timeout: o.timeout, stall: newStallWatchdog(o.stallTimeout)}
+}
+
+// NewReadSeeker wraps an [io.ReadSeeker] to handle context cancellation.
+//
+// Context state is checked BEFORE every Read and Seek.
+func NewReadSeeker(ctx context.Context, rs io.ReadSeeker, opts ...Option) io.ReadSeeker {
+	return &readSeeker{
+		reader: newReader(ctx, rs, applyOptions(opts)),
+		s:      rs,
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+type readerAt struct {
+	ctx     context.Context
+	ra      io.ReaderAt
+	timeout time.Duration
+	stall   *stallWatchdog
+}
+
+// ReadAt implements [io.ReaderAt], but with context awareness.
+func (r *readerAt) ReadAt(p []byte, off int64) (n int, err error) {
+	if err := r.stall.err(r.ctx); err != nil {
+		return 0, err
+	}
+
+	ctx, done := r.stall.watch(r.ctx)
+	defer done()
+	r.stall.begin()
+	if r.timeout > 0 || r.stall != nil {
+		n, err = readFnWithTimeout(ctx, r.timeout, func(buf []byte) (int, error) {
+			return r.ra.ReadAt(buf, off)
+		}, p)
+	} else {
+		n, err = r.ra.ReadAt(p, off)
+	}
+	r.stall.end(n)
+	return n, err
+}
+
+type writerAt struct {
+	ctx     context.Context
+	wa      io.WriterAt
+	timeout time.Duration
+	stall   *stallWatchdog
+}
+
+// WriteAt implements [io.WriterAt], but with context awareness.
+func (w *writerAt) WriteAt(p []byte, off int64) (n int, err error) {
+	if err := w.stall.err(w.ctx); err != nil {
+		return 0, err
+	}
+
+	ctx, done := w.stall.watch(w.ctx)
+	defer done()
+	w.stall.begin()
+	if w.timeout > 0 || w.stall != nil {
+		n, err = writeFnWithTimeout(ctx, w.timeout, func(buf []byte) (int, error) {
+			return w.wa.WriteAt(buf, off)
+		}, p)
+	} else {
+		n, err = w.wa.WriteAt(p, off)
+	}
+	w.stall.end(n)
+	return n, err
+}
+
+type readSeeker struct {
+	reader
+	s io.Seeker
+}
+
+// Seek implements [io.Seeker], but with context awareness.
+func (r *readSeeker) Seek(offset int64, whence int) (int64, error) {
+	if err := r.stall.err(r.ctx); err != nil {
+		return 0, err
+	}
+	return r.s.Seek(offset, whence)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/file/contextio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderAt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := contextio.NewReaderAt(ctx, bytes.NewReader([]byte("hello world")))
+
+	p := make([]byte, 5)
+	n, err := r.ReadAt(p, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "world", string(p))
+
+	cancel()
+	_, err = r.ReadAt(p, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWriterAt(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "test.bin"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := contextio.NewWriterAt(ctx, f)
+
+	_, err = w.WriteAt([]byte("world"), 6)
+	require.NoError(t, err)
+	_, err = w.WriteAt([]byte("hello "), 0)
+	require.NoError(t, err)
+
+	cancel()
+	_, err = w.WriteAt([]byte("!"), 11)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+}
+
+func TestReadSeeker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := contextio.NewReadSeeker(ctx, bytes.NewReader([]byte("hello world")))
+
+	pos, err := r.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), pos)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(data))
+
+	cancel()
+	_, err = r.Seek(0, io.SeekStart)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, context.Canceled)
+}
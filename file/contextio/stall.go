@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrStalled is the cause of cancellation when no bytes have been transferred for the
+// duration specified using [WithStallTimeout].
+var ErrStalled = errors.New("contextio: stalled, no data transferred")
+
+// stallWatchdog detects when an operation has not made progress for the specified duration.
+// The timer is only armed while an operation is in progress, which means a wrapper that is left idle
+// is never considered stalled and has no timer pending.
+// Once stalled, the watchdog remains stalled. Nothing is registered with the wrapped context outside of an
+// operation, which means a wrapper can be dropped without being closed.
+// All methods are safe to call on a nil watchdog.
+type stallWatchdog struct {
+	d       time.Duration
+	stalled context.Context // canceled with ErrStalled
+	cancel  context.CancelCauseFunc
+
+	mu     sync.Mutex
+	timer  *time.Timer // created by the first operation
+	active int         // number of operations in progress
+}
+
+// Create a new watchdog that considers an operation stalled when it makes no progress within d.
+// Returns nil when d is not positive.
+func newStallWatchdog(d time.Duration) *stallWatchdog {
+	if d <= 0 {
+		return nil
+	}
+
+	// Not derived from the wrapped context so that nothing needs to be released when the wrapper is dropped
+	stalled, cancel := context.WithCancelCause(context.Background())
+	return &stallWatchdog{d: d, stalled: stalled, cancel: cancel}
+}
+
+// Return the cause of the context cancellation or ErrStalled once an operation has stalled.
+func (wd *stallWatchdog) err(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	if wd != nil && wd.stalled.Err() != nil {
+		return context.Cause(wd.stalled)
+	}
+	return nil
+}
+
+// Derive a context for a single operation that is also canceled with ErrStalled when the operation stalls.
+// The returned function must be called once the operation has completed.
+func (wd *stallWatchdog) watch(ctx context.Context) (context.Context, func()) {
+	if wd == nil {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(wd.stalled, func() {
+		cancel(context.Cause(wd.stalled))
+	})
+	return ctx, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// Arrange to call f once an operation has stalled.
+// The returned function stops f from being called.
+func (wd *stallWatchdog) afterStall(f func()) func() bool {
+	if wd == nil {
+		return func() bool { return false }
+	}
+	return context.AfterFunc(wd.stalled, f)
+}
+
+// Arm the timer when an operation starts.
+func (wd *stallWatchdog) begin() {
+	if wd == nil {
+		return
+	}
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	wd.active++
+	if wd.active > 1 {
+		return
+	}
+	if wd.timer == nil {
+		wd.timer = time.AfterFunc(wd.d, func() {
+			wd.cancel(ErrStalled)
+		})
+		return
+	}
+	wd.timer.Reset(wd.d)
+}
+
+// Disarm the timer once no operation is in progress, otherwise restart it if n bytes were transferred.
+func (wd *stallWatchdog) end(n int) {
+	if wd == nil {
+		return
+	}
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	wd.active--
+	if wd.active == 0 {
+		wd.timer.Stop()
+	} else if n > 0 {
+		wd.timer.Reset(wd.d)
+	}
+}
+
+// Stop the timer, after which the wrapper must no longer be used.
+func (wd *stallWatchdog) stop() {
+	if wd == nil {
+		return
+	}
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	if wd.timer != nil {
+		wd.timer.Stop()
+	}
+}
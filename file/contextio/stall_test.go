@@ -0,0 +1,219 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/file/contextio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderStalled(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	go func() {
+		// Keep making progress for a while and then stall
+		for i := 0; i < 5; i++ {
+			time.Sleep(20 * time.Millisecond)
+			if _, err := pw.Write([]byte{byte(i)}); err != nil {
+				return
+			}
+		}
+	}()
+
+	r := contextio.NewReader(context.Background(), pr, contextio.WithStallTimeout(100*time.Millisecond))
+	data, err := io.ReadAll(r)
+	require.ErrorIs(t, err, contextio.ErrStalled)
+	assert.Equal(t, []byte{0, 1, 2, 3, 4}, data)
+
+	// Remains stalled
+	_, err = r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, contextio.ErrStalled)
+}
+
+func TestWriterStalled(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	w := contextio.NewWriter(context.Background(), pw, contextio.WithStallTimeout(20*time.Millisecond))
+	_, err := w.Write([]byte("hello"))
+	assert.ErrorIs(t, err, contextio.ErrStalled)
+}
+
+func TestReadCloserStalledClosesOnCancel(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	r := contextio.NewReadCloser(context.Background(), pr,
+		contextio.WithStallTimeout(20*time.Millisecond), contextio.WithCloseOnCancel())
+	_, err := r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, contextio.ErrStalled)
+
+	// The pipe is closed by the cancellation
+	require.Eventually(t, func() bool {
+		_, err := pw.Write([]byte("x"))
+		return errors.Is(err, io.ErrClosedPipe)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Close())
+}
+
+func TestReaderNotStalledAtEOF(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("hello"))
+		pw.Close()
+	}()
+
+	r := contextio.NewReader(context.Background(), pr, contextio.WithStallTimeout(10*time.Millisecond))
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	time.Sleep(30 * time.Millisecond)
+	_, err = r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestWriterIdleNotStalled(t *testing.T) {
+	var buf bytes.Buffer
+	w := contextio.NewWriter(context.Background(), &buf, contextio.WithStallTimeout(10*time.Millisecond))
+	_, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	// Time spent idle between operations does not count towards a stall
+	time.Sleep(30 * time.Millisecond)
+	_, err = w.Write([]byte(" world"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", buf.String())
+}
+
+func TestWriteCloserIdleReleasedOnClose(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+
+	closed := make(chan struct{})
+	wc := contextio.NewWriteCloser(context.Background(), &notifyCloser{WriteCloser: pw, closed: closed},
+		contextio.WithStallTimeout(10*time.Millisecond), contextio.WithCloseOnCancel())
+	_, err := wc.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	// An idle writer is not closed by a stall
+	select {
+	case <-closed:
+		t.Fatal("expected the idle writer not to be closed")
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	require.NoError(t, wc.Close())
+	<-closed
+}
+
+func TestStallTimeoutReleasedAfterOperation(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx := &trackingContext{Context: parent}
+	opt := contextio.WithStallTimeout(time.Second)
+
+	var buf bytes.Buffer
+	_, err := contextio.NewWriter(ctx, &buf, opt).Write([]byte("hello"))
+	require.NoError(t, err)
+	_, err = contextio.NewReader(ctx, &buf, opt).Read(make([]byte, 5))
+	require.NoError(t, err)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "unit-testing"))
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = contextio.NewWriterAt(ctx, f, opt).WriteAt([]byte("hello"), 0)
+	require.NoError(t, err)
+	_, err = contextio.NewReaderAt(ctx, f, opt).ReadAt(make([]byte, 5), 0)
+	require.NoError(t, err)
+
+	// Wrappers that are not closed must not keep anything registered with the context
+	assert.Equal(t, int32(0), ctx.registered.Load())
+}
+
+func TestByteReaderStalled(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	go func() {
+		_, _ = pw.Write([]byte{1})
+		time.Sleep(100 * time.Millisecond)
+		_, _ = pw.Write([]byte{2})
+	}()
+
+	r := contextio.NewByteReader(context.Background(), pr,
+		contextio.WithStallTimeout(20*time.Millisecond), contextio.WithByteCheckInterval(1))
+	b, err := r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte(1), b)
+
+	// The ReadByte can't be abandoned but the stall is reported afterwards
+	_, err = r.ReadByte()
+	require.NoError(t, err)
+	_, err = r.ReadByte()
+	assert.ErrorIs(t, err, contextio.ErrStalled)
+}
+
+// trackingContext counts the cancellation registrations that have not been released.
+type trackingContext struct {
+	context.Context
+	registered atomic.Int32
+}
+
+// Hide the parent's values so that derived contexts register using AfterFunc.
+func (c *trackingContext) Value(key any) any {
+	return nil
+}
+
+func (c *trackingContext) AfterFunc(f func()) func() bool {
+	c.registered.Add(1)
+	stop := context.AfterFunc(c.Context, f)
+	var once sync.Once
+	return func() bool {
+		once.Do(func() { c.registered.Add(-1) })
+		return stop()
+	}
+}
+
+type notifyCloser struct {
+	io.WriteCloser
+	closed chan struct{}
+}
+
+func (c *notifyCloser) Close() error {
+	close(c.closed)
+	return c.WriteCloser.Close()
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// TimeoutError is returned when an individual Read or Write did not complete within the
+// timeout specified using [WithTimeout].
+type TimeoutError struct {
+	Op       string // "read" or "write"
+	Duration time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("contextio: %s timed out after %s", e.Op, e.Duration)
+}
+
+// Timeout reports that the error is a timeout. Matches the net.Error convention.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+//-----------------------------------------------------------------------------
+
+type ioResult struct {
+	n   int
+	err error
+}
+
+// Read into p while waiting at most timeout.
+func readWithTimeout(ctx context.Context, timeout time.Duration, r io.Reader, p []byte) (int, error) {
+	return readFnWithTimeout(ctx, timeout, r.Read, p)
+}
+
+// Write p while waiting at most timeout.
+func writeWithTimeout(ctx context.Context, timeout time.Duration, w io.Writer, p []byte) (int, error) {
+	return writeFnWithTimeout(ctx, timeout, w.Write, p)
+}
+
+// Call the read function while waiting at most timeout.
+// The read is performed using a separate buffer so that p is not written to after a timeout.
+func readFnWithTimeout(ctx context.Context, timeout time.Duration, read func([]byte) (int, error), p []byte) (int, error) {
+	buf := make([]byte, len(p))
+	n, err := withTimeout(ctx, timeout, "read", func() (int, error) {
+		return read(buf)
+	})
+	copy(p, buf[:n])
+	return n, err
+}
+
+// Call the write function while waiting at most timeout.
+// A copy of p is written so that the caller may reuse p after a timeout.
+func writeFnWithTimeout(ctx context.Context, timeout time.Duration, write func([]byte) (int, error), p []byte) (int, error) {
+	buf := make([]byte, len(p))
+	copy(buf, p)
+	return withTimeout(ctx, timeout, "write", func() (int, error) {
+		return write(buf)
+	})
+}
+
+// Call fn and wait for it to complete, the context to be canceled or the timeout to expire.
+// A timeout of 0 means only the context is used.
+func withTimeout(ctx context.Context, timeout time.Duration, op string, fn func() (int, error)) (int, error) {
+	opCtx, cancel := ctx, context.CancelFunc(func() {})
+	if timeout > 0 {
+		opCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	resultCh := make(chan ioResult, 1)
+	go func() {
+		n, err := fn()
+		resultCh <- ioResult{n: n, err: err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.n, result.err
+	case <-opCtx.Done():
+		if ctx.Err() != nil {
+			return 0, context.Cause(ctx)
+		}
+		return 0, &TimeoutError{Op: op, Duration: timeout}
+	}
+}
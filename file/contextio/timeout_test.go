@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package contextio_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/file/contextio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderWithTimeout(t *testing.T) {
+	r := contextio.NewReader(context.Background(), bytes.NewReader([]byte("hello")),
+		contextio.WithTimeout(time.Second))
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	r = contextio.NewReader(context.Background(), pr, contextio.WithTimeout(10*time.Millisecond))
+	_, err = r.Read(make([]byte, 1))
+
+	var timeoutErr *contextio.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "read", timeoutErr.Op)
+	assert.True(t, timeoutErr.Timeout())
+	assert.Equal(t, 10*time.Millisecond, timeoutErr.Duration)
+}
+
+func TestWriterWithTimeout(t *testing.T) {
+	var buf bytes.Buffer
+	w := contextio.NewWriter(context.Background(), &buf, contextio.WithTimeout(time.Second))
+	_, err := io.Copy(w, bytes.NewReader([]byte("hello")))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", buf.String())
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	w = contextio.NewWriter(context.Background(), pw, contextio.WithTimeout(10*time.Millisecond))
+	_, err = w.Write([]byte("hello"))
+
+	var timeoutErr *contextio.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "write", timeoutErr.Op)
+}
+
+func TestTimeoutParentCanceled(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	r := contextio.NewReader(ctx, pr, contextio.WithTimeout(time.Minute))
+	_, err := r.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var timeoutErr *contextio.TimeoutError
+	assert.NotErrorAs(t, err, &timeoutErr)
+}
+
+func TestReadCloserWithTimeout(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	r := contextio.NewReadCloser(context.Background(), pr, contextio.WithTimeout(10*time.Millisecond))
+	_, err := r.Read(make([]byte, 1))
+
+	var timeoutErr *contextio.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.NoError(t, r.Close())
+}
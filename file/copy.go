@@ -30,7 +30,7 @@ import (
 )
 
 // Copy the source file to the destination and return the number of bytes that were copied.
-func CopyFile(ctx context.Context, source string, destination string) (int64, error) {
+func CopyFile(ctx context.Context, source string, destination string, opts ...Option) (int64, error) {
 	src, dest, srcInfo, err := openFilesForCopying(source, destination)
 	if err != nil {
 		return 0, fmt.Errorf("failed to copy the file %q to %q. %w", source, destination, err)
@@ -38,7 +38,7 @@ func CopyFile(ctx context.Context, source string, destination string) (int64, er
 	defer src.Close()
 	defer dest.Close()
 
-	wc, err := copyN(ctx, src, dest, srcInfo.Size())
+	wc, err := copyN(ctx, src, dest, srcInfo.Size(), applyOptions(opts))
 	if err != nil {
 		return wc, fmt.Errorf("failed to copy the file %q to %q. %w", source, destination, err)
 	}
@@ -47,7 +47,7 @@ func CopyFile(ctx context.Context, source string, destination string) (int64, er
 }
 
 // Copy N bytes from the source file to the destination and return the number of bytes that were copied.
-func CopyFileN(ctx context.Context, source string, destination string, count int64) (int64, error) {
+func CopyFileN(ctx context.Context, source string, destination string, count int64, opts ...Option) (int64, error) {
 	src, dest, _, err := openFilesForCopying(source, destination)
 	if err != nil {
 		return 0, fmt.Errorf("failed to copy the file %q to %q. %w", source, destination, err)
@@ -55,7 +55,7 @@ func CopyFileN(ctx context.Context, source string, destination string, count int
 	defer src.Close()
 	defer dest.Close()
 
-	wc, err := copyN(ctx, src, dest, count)
+	wc, err := copyN(ctx, src, dest, count, applyOptions(opts))
 	if err != nil {
 		return wc, fmt.Errorf("failed to copy the file %q to %q. %w", source, destination, err)
 	}
@@ -84,10 +84,22 @@ func openFilesForCopying(source string, destination string) (*os.File, *os.File,
 	return src, dest, srcStat, nil
 }
 
-func copyN(ctx context.Context, src io.Reader, dest io.Writer, count int64) (int64, error) {
+func copyN(ctx context.Context, src io.Reader, dest io.Writer, count int64, o options) (int64, error) {
 	in := contextio.NewReader(ctx, src)
 	out := contextio.NewWriter(ctx, dest)
 
-	wc, err := io.CopyN(out, in, count)
+	if o.pool == nil {
+		wc, err := io.CopyN(out, in, count)
+		return wc, err
+	}
+
+	buffer := o.pool.Get(copyBufferSize)
+	defer o.pool.Put(buffer)
+
+	// Hide io.ReaderFrom and io.WriterTo so that the pooled buffer is used by io.CopyBuffer
+	wc, err := io.CopyBuffer(struct{ io.Writer }{out}, struct{ io.Reader }{io.LimitReader(in, count)}, buffer)
+	if err == nil && wc < count {
+		err = io.EOF
+	}
 	return wc, err
 }
@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/andrejacobs/go-aj/ajio"
 	"github.com/andrejacobs/go-aj/file"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -80,3 +81,36 @@ func TestCopyFileN(t *testing.T) {
 
 	assert.Equal(t, "The quick", string(data))
 }
+
+func TestCopyFileWithBufferPool(t *testing.T) {
+	expected := "The quick brown fox jumped over the lazy dog!"
+	src, err := os.CreateTemp("", "unit-test-source")
+	require.NoError(t, err)
+	defer os.Remove(src.Name())
+	_, err = src.WriteString(expected)
+	require.NoError(t, err)
+	require.NoError(t, src.Close())
+
+	pool := ajio.NewBufferPool()
+	destPath := filepath.Join(t.TempDir(), "unit-test-dest")
+	wc, err := file.CopyFile(context.Background(), src.Name(), destPath, file.WithBufferPool(pool))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(expected)), wc)
+
+	data, err := os.ReadFile(destPath)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+
+	wc, err = file.CopyFileN(context.Background(), src.Name(), destPath, 9, file.WithBufferPool(pool))
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), wc)
+
+	data, err = os.ReadFile(destPath)
+	require.NoError(t, err)
+	assert.Equal(t, expected[:9], string(data))
+
+	// Asking for more than what is available
+	wc, err = file.CopyFileN(context.Background(), src.Name(), destPath, 100, file.WithBufferPool(pool))
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, int64(len(expected)), wc)
+}
@@ -37,8 +37,8 @@ import (
 
 // Do buffered reads from rd and write to the hasher and optional io.Writer.
 // Return the calculated hash and the total number of bytes copied.
-func HashFromReader(ctx context.Context, rd io.Reader, hasher hash.Hash, w io.Writer) ([]byte, uint64, error) {
-	r := contextio.NewReader(ctx, bufio.NewReader(rd))
+func HashFromReader(ctx context.Context, rd io.Reader, hasher hash.Hash, w io.Writer, opts ...Option) ([]byte, uint64, error) {
+	o := applyOptions(opts)
 
 	var dest io.Writer
 	if (w != nil) && !reflect.ValueOf(w).IsNil() {
@@ -47,7 +47,15 @@ func HashFromReader(ctx context.Context, rd io.Reader, hasher hash.Hash, w io.Wr
 		dest = hasher
 	}
 
-	count, err := io.Copy(dest, r)
+	var count int64
+	var err error
+	if o.pool != nil {
+		buffer := o.pool.Get(copyBufferSize)
+		defer o.pool.Put(buffer)
+		count, err = io.CopyBuffer(dest, struct{ io.Reader }{contextio.NewReader(ctx, rd)}, buffer)
+	} else {
+		count, err = io.Copy(dest, contextio.NewReader(ctx, bufio.NewReader(rd)))
+	}
 	if err != nil {
 		return nil, uint64(count), err
 	}
@@ -57,28 +65,28 @@ func HashFromReader(ctx context.Context, rd io.Reader, hasher hash.Hash, w io.Wr
 
 // Hash the specified file and optionally copy the read bytes to the io.Writer.
 // Return the calculated hash and the total number of bytes copied.
-func Hash(ctx context.Context, path string, hasher hash.Hash, w io.Writer) ([]byte, uint64, error) {
+func Hash(ctx context.Context, path string, hasher hash.Hash, w io.Writer, opts ...Option) ([]byte, uint64, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to hash the file '%s'. %w", path, err)
 	}
 	defer f.Close()
 
-	return HashFromReader(ctx, f, hasher, w)
+	return HashFromReader(ctx, f, hasher, w, opts...)
 }
 
-func HashMD5(ctx context.Context, path string, w io.Writer) ([]byte, uint64, error) {
-	return Hash(ctx, path, md5.New(), w) // #nosec G401 -- MD5 is not used for cryptography
+func HashMD5(ctx context.Context, path string, w io.Writer, opts ...Option) ([]byte, uint64, error) {
+	return Hash(ctx, path, md5.New(), w, opts...) // #nosec G401 -- MD5 is not used for cryptography
 }
 
-func HashSHA1(ctx context.Context, path string, w io.Writer) ([]byte, uint64, error) {
-	return Hash(ctx, path, sha1.New(), w) // #nosec G401 -- SHA1 is not used for cryptography
+func HashSHA1(ctx context.Context, path string, w io.Writer, opts ...Option) ([]byte, uint64, error) {
+	return Hash(ctx, path, sha1.New(), w, opts...) // #nosec G401 -- SHA1 is not used for cryptography
 }
 
-func HashSHA256(ctx context.Context, path string, w io.Writer) ([]byte, uint64, error) {
-	return Hash(ctx, path, sha256.New(), w)
+func HashSHA256(ctx context.Context, path string, w io.Writer, opts ...Option) ([]byte, uint64, error) {
+	return Hash(ctx, path, sha256.New(), w, opts...)
 }
 
-func HashSHA512(ctx context.Context, path string, w io.Writer) ([]byte, uint64, error) {
-	return Hash(ctx, path, sha512.New(), w)
+func HashSHA512(ctx context.Context, path string, w io.Writer, opts ...Option) ([]byte, uint64, error) {
+	return Hash(ctx, path, sha512.New(), w, opts...)
 }
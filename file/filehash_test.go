@@ -32,6 +32,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/andrejacobs/go-aj/ajio"
 	"github.com/andrejacobs/go-aj/file"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -104,6 +105,20 @@ func TestOptionalWriter(t *testing.T) {
 	assert.Equal(t, expected, string(result))
 }
 
+func TestHashWithBufferPool(t *testing.T) {
+	tempFile, err := makeHashFile()
+	require.NoError(t, err)
+	defer os.Remove(tempFile)
+
+	pool := ajio.NewBufferPool()
+	w := bytes.Buffer{}
+	hash, count, err := file.HashSHA256(context.Background(), tempFile, &w, file.WithBufferPool(pool))
+	require.NoError(t, err)
+	assert.Equal(t, expectedSHA256, fmt.Sprintf("%x", hash))
+	assert.Equal(t, uint64(w.Len()), count)
+	assert.Equal(t, "The quick brown fox jumped over the lazy dog!", w.String())
+}
+
 //-----------------------------------------------------------------------------
 
 func makeHashFile() (string, error) {
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/andrejacobs/go-aj/concurrency"
+)
+
+// ParallelWalkFn is called for each file found by ParallelWalk.
+type ParallelWalkFn func(ctx context.Context, path string, d fs.DirEntry) error
+
+// ParallelWalk walks the file tree rooted at root using a default [Walker] and calls fn for each file
+// using the specified number of workers. See [Walker.ParallelWalk] for details.
+func ParallelWalk(ctx context.Context, root string, workers int, fn ParallelWalkFn) error {
+	return NewWalker().ParallelWalk(ctx, root, workers, fn)
+}
+
+// ParallelWalk walks the file tree rooted at root and calls fn for each file that was not filtered
+// using the specified number of workers. The order in which fn is called is not deterministic.
+//
+// fn is only called for files and not for directories, since directories can't be skipped
+// once the walk has moved on. Use the DirIncluder and DirExcluder to filter directories.
+//
+// Errors returned by fn (and panics) and errors that arise visiting files and directories do not stop
+// the walk. They are collected and returned joined using errors.Join after the walk has finished.
+// The walk is stopped when the context is canceled.
+func (w *Walker) ParallelWalk(ctx context.Context, root string, workers int, fn ParallelWalkFn) error {
+	type walkItem struct {
+		path string
+		d    fs.DirEntry
+	}
+
+	pool := concurrency.NewPool(ctx, workers, func(ctx context.Context, item walkItem) error {
+		if err := fn(ctx, item.path, item.d); err != nil {
+			return fmt.Errorf("failed to process the path %q. %w", item.path, err)
+		}
+		return nil
+	})
+
+	var walkErrs []error
+	walkErr := w.Walk(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			walkErrs = append(walkErrs, fmt.Errorf("failed to walk the path %q. %w", path, err))
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		return pool.Submit(walkItem{path: path, d: d})
+	})
+
+	poolErr := pool.Wait()
+	if walkErr != nil && ctx.Err() == nil {
+		walkErrs = append(walkErrs, walkErr)
+	}
+	return errors.Join(append(walkErrs, poolErr)...)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package file_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelWalk(t *testing.T) {
+	expected := make([]string, 0, 10)
+	err := filepath.WalkDir(tempDir, func(path string, d fs.DirEntry, err error) error {
+		if !d.IsDir() {
+			expected = append(expected, path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	result := make([]string, 0, 10)
+	err = file.ParallelWalk(context.Background(), tempDir, 4, func(ctx context.Context, path string, d fs.DirEntry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		result = append(result, path)
+		return nil
+	})
+	require.NoError(t, err)
+
+	slices.Sort(result)
+	assert.ElementsMatch(t, expected, result)
+}
+
+func TestParallelWalkCollectsErrors(t *testing.T) {
+	errBad := errors.New("bad")
+
+	w := file.NewWalker()
+	w.FileIncluder = func(path string, d fs.DirEntry) (bool, error) {
+		return d.Name() == "a" || d.Name() == "b", nil
+	}
+
+	err := w.ParallelWalk(context.Background(), tempDir, 2, func(ctx context.Context, path string, d fs.DirEntry) error {
+		return errBad
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errBad)
+	assert.Contains(t, err.Error(), filepath.Join(tempDir, "a"))
+	assert.Contains(t, err.Error(), filepath.Join(tempDir, "b"))
+}
+
+func TestParallelWalkMissingRoot(t *testing.T) {
+	err := file.ParallelWalk(context.Background(), filepath.Join(tempDir, "does-not-exist"), 2,
+		func(ctx context.Context, path string, d fs.DirEntry) error {
+			return nil
+		})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParallelWalkCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := file.ParallelWalk(ctx, tempDir, 2, func(ctx context.Context, path string, d fs.DirEntry) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
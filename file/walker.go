@@ -199,8 +199,9 @@ func MatchAppleProtected(next MatchPathFn) MatchPathFn {
 
 // MatchRegex middleware takes a slice of regular expression patterns and will check
 // a path if any of the expressions matched.
+// The compiled expressions are shared using matches.DefaultCache.
 func MatchRegex(expressions []string, next MatchPathFn) (MatchPathFn, error) {
-	matcher, err := matches.NewRegexPathMatcher(expressions)
+	matcher, err := matches.DefaultCache.RegexPathMatcher(expressions)
 	if err != nil {
 		return nil, err
 	}
@@ -216,3 +217,28 @@ func MatchRegex(expressions []string, next MatchPathFn) (MatchPathFn, error) {
 		return next(path, d)
 	}, nil
 }
+
+// MatchPathMatcher creates a MatchPathFn from a matches.PathMatcher, which can be composed using
+// matches.All, matches.Any and matches.Not. If the matcher also distinguishes directories from files
+// (e.g. matches.GitignoreMatcher) then it is told whether the path is a directory.
+func MatchPathMatcher(matcher matches.PathMatcher) MatchPathFn {
+	if dm, ok := matcher.(dirAwareMatcher); ok {
+		return func(path string, d fs.DirEntry) (bool, error) {
+			return dm.MatchPath(path, d.IsDir()), nil
+		}
+	}
+
+	return func(path string, d fs.DirEntry) (bool, error) {
+		return matcher.Match(path)
+	}
+}
+
+type dirAwareMatcher interface {
+	MatchPath(path string, isDir bool) bool
+}
+
+// MatchEntryMatcher creates a MatchPathFn from a matches.EntryMatcher (e.g. matches.SizeMatcher,
+// matches.ModTimeMatcher or matches.ModeMatcher).
+func MatchEntryMatcher(matcher matches.EntryMatcher) MatchPathFn {
+	return matcher.MatchEntry
+}
@@ -28,6 +28,7 @@ import (
 	"testing"
 
 	"github.com/andrejacobs/go-aj/file"
+	"github.com/andrejacobs/go-aj/matches"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -344,3 +345,68 @@ func (td testDirEntry) Type() fs.FileMode {
 func (td testDirEntry) Info() (fs.FileInfo, error) {
 	return nil, nil
 }
+
+func TestWalkerMatchPathMatcher(t *testing.T) {
+	expected := make([]string, 0, 10)
+	err := filepath.WalkDir(tempDir, func(path string, d fs.DirEntry, err error) error {
+		if d.IsDir() && d.Name() == "g" {
+			return fs.SkipDir
+		}
+		if !d.IsDir() && (d.Name() == "a" || d.Name() == "b" || d.Name() == ".DS_Store") {
+			return nil
+		}
+		expected = append(expected, path)
+		return nil
+	})
+	require.NoError(t, err)
+
+	result := make([]string, 0, 10)
+	var fn fs.WalkDirFunc = func(path string, d fs.DirEntry, err error) error {
+		result = append(result, path)
+		return nil
+	}
+
+	dsStore, err := matches.NewGlobPathMatcher([]string{"**/.DS_Store"})
+	require.NoError(t, err)
+	ab, err := matches.NewRegexPathMatcher([]string{"^[ab]$"})
+	require.NoError(t, err)
+	ignoreG, err := matches.NewGitignoreMatcher([]string{"g/"})
+	require.NoError(t, err)
+
+	w := file.NewWalker()
+	w.FileExcluder = file.MatchPathMatcher(matches.Any(dsStore, ab))
+	w.DirExcluder = file.MatchPathMatcher(ignoreG)
+	err = w.Walk(tempDir, fn)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, expected, result)
+}
+
+func TestWalkerMatchEntryMatcher(t *testing.T) {
+	expected := make([]string, 0, 10)
+	err := filepath.WalkDir(tempDir, func(path string, d fs.DirEntry, err error) error {
+		if !d.IsDir() {
+			info, err := d.Info()
+			require.NoError(t, err)
+			if info.Size() < 20 {
+				return nil
+			}
+		}
+		expected = append(expected, path)
+		return nil
+	})
+	require.NoError(t, err)
+
+	result := make([]string, 0, 10)
+	var fn fs.WalkDirFunc = func(path string, d fs.DirEntry, err error) error {
+		result = append(result, path)
+		return nil
+	}
+
+	w := file.NewWalker()
+	w.FileIncluder = file.MatchEntryMatcher(matches.NewSizeMatcher(20, -1))
+	err = w.Walk(tempDir, fn)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, expected, result)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Cache memoizes compiled matchers keyed by their patterns and options so that identical pattern sets
+// are only compiled once. The cached matchers are shared and must not be modified.
+// Cache is safe for concurrent use by multiple goroutines.
+type Cache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[cacheKey]*list.Element
+	order      *list.List // front is the most recently used
+}
+
+// DefaultCache is a shared Cache that can be used when there is no need for a dedicated one.
+var DefaultCache = NewCache(256)
+
+// Create a new Cache that keeps at most maxEntries compiled matchers by evicting the least recently used.
+// A maxEntries of 0 or less means there is no limit.
+func NewCache(maxEntries int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		entries:    make(map[cacheKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Return the cached RegexList for the expressions and options or compile and cache a new one.
+func (c *Cache) RegexList(expressions []string, opts ...Option) (*RegexList, error) {
+	return cached(c, "regexlist", expressions, opts, func() (*RegexList, error) {
+		return NewRegexList(expressions, opts...)
+	})
+}
+
+// Return the cached RegexPathMatcher for the expressions and options or compile and cache a new one.
+func (c *Cache) RegexPathMatcher(expressions []string, opts ...Option) (*RegexPathMatcher, error) {
+	return cached(c, "regexpath", expressions, opts, func() (*RegexPathMatcher, error) {
+		return NewRegexPathMatcher(expressions, opts...)
+	})
+}
+
+// Return the cached GlobPathMatcher for the patterns and options or compile and cache a new one.
+func (c *Cache) GlobPathMatcher(patterns []string, opts ...Option) (*GlobPathMatcher, error) {
+	return cached(c, "globpath", patterns, opts, func() (*GlobPathMatcher, error) {
+		return NewGlobPathMatcher(patterns, opts...)
+	})
+}
+
+// Return the number of cached matchers.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Remove all the cached matchers.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.order.Init()
+}
+
+//-----------------------------------------------------------------------------
+
+type cacheKey struct {
+	kind     string
+	patterns string
+	options  options
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	value any
+}
+
+// Return the cached value or create it. Errors are not cached.
+// Compiling happens outside of the lock which means concurrent misses for the same key may compile more
+// than once, the first one stored wins.
+func cached[T any](c *Cache, kind string, patterns []string, opts []Option, create func() (T, error)) (T, error) {
+	key := cacheKey{
+		kind:     kind,
+		patterns: encodePatterns(patterns),
+		options:  applyOptions(opts),
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		c.mu.Unlock()
+		return elem.Value.(*cacheEntry).value.(T), nil
+	}
+	c.mu.Unlock()
+
+	value, err := create()
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return elem.Value.(*cacheEntry).value.(T), nil
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value})
+	if c.maxEntries > 0 && len(c.entries) > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+
+	return value, nil
+}
+
+// Encode the patterns into a single string with each pattern prefixed by its length so that
+// different pattern sets can never produce the same key.
+func encodePatterns(patterns []string) string {
+	var sb strings.Builder
+	for _, p := range patterns {
+		sb.WriteString(strconv.Itoa(len(p)))
+		sb.WriteByte(':')
+		sb.WriteString(p)
+	}
+	return sb.String()
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/matches"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheRegexList(t *testing.T) {
+	c := matches.NewCache(0)
+
+	l1, err := c.RegexList([]string{`\d+`, `abc`})
+	require.NoError(t, err)
+	l2, err := c.RegexList([]string{`\d+`, `abc`})
+	require.NoError(t, err)
+	assert.Same(t, l1, l2)
+	assert.Equal(t, 1, c.Len())
+
+	// Different sets, order and options produce different matchers
+	l3, err := c.RegexList([]string{`abc`, `\d+`})
+	require.NoError(t, err)
+	assert.NotSame(t, l1, l3)
+
+	l4, err := c.RegexList([]string{`\d+`, `abc`}, matches.WithCaseInsensitive())
+	require.NoError(t, err)
+	assert.NotSame(t, l1, l4)
+	assert.True(t, l4.MatchesAny("ABC"))
+	assert.False(t, l1.MatchesAny("ABC"))
+
+	l5, err := c.RegexList([]string{`\d+abc`})
+	require.NoError(t, err)
+	assert.NotSame(t, l1, l5)
+	assert.Equal(t, 4, c.Len())
+
+	_, err = c.RegexList([]string{`(`})
+	assert.Error(t, err)
+	assert.Equal(t, 4, c.Len())
+
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+	l6, err := c.RegexList([]string{`\d+`, `abc`})
+	require.NoError(t, err)
+	assert.NotSame(t, l1, l6)
+}
+
+func TestCachePathMatchers(t *testing.T) {
+	c := matches.NewCache(0)
+
+	r1, err := c.RegexPathMatcher([]string{`\.go$`})
+	require.NoError(t, err)
+	r2, err := c.RegexPathMatcher([]string{`\.go$`})
+	require.NoError(t, err)
+	assert.Same(t, r1, r2)
+
+	g1, err := c.GlobPathMatcher([]string{"**/*.go"})
+	require.NoError(t, err)
+	g2, err := c.GlobPathMatcher([]string{"**/*.go"})
+	require.NoError(t, err)
+	assert.Same(t, g1, g2)
+
+	matched, err := g1.Match("src/main.go")
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	// The same patterns for a different kind of matcher are cached separately
+	_, err = c.RegexList([]string{`\.go$`})
+	require.NoError(t, err)
+	assert.Equal(t, 3, c.Len())
+
+	_, err = c.GlobPathMatcher([]string{"{a,"})
+	assert.Error(t, err)
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := matches.NewCache(2)
+
+	a, err := c.RegexList([]string{"a"})
+	require.NoError(t, err)
+	_, err = c.RegexList([]string{"b"})
+	require.NoError(t, err)
+
+	// Use a so that b is the least recently used
+	a2, err := c.RegexList([]string{"a"})
+	require.NoError(t, err)
+	assert.Same(t, a, a2)
+
+	_, err = c.RegexList([]string{"c"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Len())
+
+	a3, err := c.RegexList([]string{"a"})
+	require.NoError(t, err)
+	assert.Same(t, a, a3)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := matches.NewCache(8)
+
+	var wg sync.WaitGroup
+	for i := range 32 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				pattern := fmt.Sprintf("p%d", (i+j)%16)
+				l, err := c.RegexList([]string{pattern})
+				assert.NoError(t, err)
+				assert.True(t, l.MatchesAny(pattern))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, c.Len(), 8)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+// PathMatcherFunc is an adapter to allow the use of an ordinary function as a PathMatcher.
+type PathMatcherFunc func(path string) (bool, error)
+
+func (f PathMatcherFunc) Match(path string) (bool, error) {
+	return f(path)
+}
+
+// All returns a PathMatcher that matches when all of the matchers match.
+// The matchers are checked in order and stop at the first one that does not match.
+// No matchers will always match.
+func All(matchers ...PathMatcher) PathMatcher {
+	return PathMatcherFunc(func(path string) (bool, error) {
+		for _, m := range matchers {
+			matched, err := m.Match(path)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+}
+
+// Any returns a PathMatcher that matches when any of the matchers match.
+// The matchers are checked in order and stop at the first one that matches.
+// No matchers will never match.
+func Any(matchers ...PathMatcher) PathMatcher {
+	return PathMatcherFunc(func(path string) (bool, error) {
+		for _, m := range matchers {
+			matched, err := m.Match(path)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	})
+}
+
+// Not returns a PathMatcher that matches when the matcher does not match.
+func Not(matcher PathMatcher) PathMatcher {
+	return PathMatcherFunc(func(path string) (bool, error) {
+		matched, err := matcher.Match(path)
+		if err != nil {
+			return false, err
+		}
+		return !matched, nil
+	})
+}
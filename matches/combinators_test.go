@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/matches"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombinators(t *testing.T) {
+	goFiles, err := matches.NewGlobPathMatcher([]string{"**/*.go"})
+	require.NoError(t, err)
+	tests, err := matches.NewRegexPathMatcher([]string{`_test\.go$`})
+	require.NoError(t, err)
+	vendor := matches.NewShellPatternPathMatcher([]string{"vendor/*"})
+
+	// Go source files that are not tests and not vendored
+	m := matches.All(goFiles, matches.Not(matches.Any(tests, vendor)))
+
+	cases := map[string]bool{
+		"main.go":          true,
+		"pkg/util.go":      true,
+		"pkg/util_test.go": false,
+		"vendor/lib.go":    false,
+		"README.md":        false,
+	}
+	for path, expected := range cases {
+		matched, err := m.Match(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, matched, path)
+	}
+
+	matched, err := matches.All().Match("x")
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = matches.Any().Match("x")
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
+
+func TestCombinatorsErrors(t *testing.T) {
+	errBad := errors.New("bad")
+	failing := matches.PathMatcherFunc(func(path string) (bool, error) {
+		return false, errBad
+	})
+	always := matches.PathMatcherFunc(func(path string) (bool, error) {
+		return true, nil
+	})
+
+	_, err := matches.All(always, failing).Match("x")
+	assert.ErrorIs(t, err, errBad)
+	_, err = matches.Any(failing, always).Match("x")
+	assert.ErrorIs(t, err, errBad)
+	_, err = matches.Not(failing).Match("x")
+	assert.ErrorIs(t, err, errBad)
+
+	// Short circuits
+	matched, err := matches.Any(always, failing).Match("x")
+	require.NoError(t, err)
+	assert.True(t, matched)
+	matched, err = matches.All(matches.Not(always), failing).Match("x")
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
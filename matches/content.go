@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// DefaultSniffBytes is the number of bytes read from the start of a file to determine the content type.
+// It is also the maximum number of bytes considered by http.DetectContentType.
+const DefaultSniffBytes = 512
+
+// ContentMatcher will match a file system path based on the content type of the file.
+// The content type is determined by reading the start of the file and checking it against MagicSignatures,
+// which includes common binary formats that http.DetectContentType does not recognise (e.g. ELF, Mach-O and PE
+// executables, SQLite databases and bzip2, xz, zstd and 7z archives), before classifying it using http.DetectContentType.
+// Paths that are not regular files never match.
+type ContentMatcher struct {
+	mimes      []string
+	sniffBytes int
+}
+
+// Create a new ContentMatcher that matches files with any of the MIME types.
+// Parameters like the charset are ignored when comparing and a MIME type ending with "/" or "/*"
+// matches all the subtypes, e.g. "text/" matches "text/plain" and "text/html".
+// sniffBytes is the number of bytes to read from the start of the file, DefaultSniffBytes is used if it is 0 or less.
+func NewContentMatcher(mimes []string, sniffBytes int) *ContentMatcher {
+	if sniffBytes <= 0 {
+		sniffBytes = DefaultSniffBytes
+	}
+
+	matcher := ContentMatcher{
+		mimes:      make([]string, 0, len(mimes)),
+		sniffBytes: sniffBytes,
+	}
+
+	for _, m := range mimes {
+		m = strings.ToLower(strings.TrimSpace(m))
+		m = strings.TrimSuffix(m, "*")
+		if !strings.HasSuffix(m, "/") {
+			m = mediaType(m)
+		}
+		matcher.mimes = append(matcher.mimes, m)
+	}
+
+	return &matcher
+}
+
+func (c *ContentMatcher) Match(path string) (bool, error) {
+	// Check before opening since opening a named pipe blocks until there is a writer
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the file info for %q. %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return false, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open the file %q. %w", path, err)
+	}
+	defer f.Close()
+
+	buffer := make([]byte, c.sniffBytes)
+	n, err := io.ReadFull(f, buffer)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, fmt.Errorf("failed to read the file %q. %w", path, err)
+	}
+
+	contentType := mediaType(detectContentType(buffer[:n]))
+	for _, m := range c.mimes {
+		if m == contentType || (strings.HasSuffix(m, "/") && strings.HasPrefix(contentType, m)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+//-----------------------------------------------------------------------------
+
+func detectContentType(data []byte) string {
+	if sig, ok := identifyMagic(MagicSignatures, data); ok && sig.MIME != "" {
+		return sig.MIME
+	}
+	return http.DetectContentType(data)
+}
+
+// Return the media type without any parameters, e.g. "text/plain; charset=utf-8" becomes "text/plain".
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt, _, _ = strings.Cut(contentType, ";")
+		return strings.ToLower(strings.TrimSpace(mt))
+	}
+	return mt
+}
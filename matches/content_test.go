@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches_test
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/matches"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentMatcher(t *testing.T) {
+	dir := t.TempDir()
+
+	pe := make([]byte, 128)
+	copy(pe, "MZ")
+	binary.LittleEndian.PutUint32(pe[0x3c:], 64)
+	copy(pe[64:], "PE\x00\x00")
+
+	files := map[string][]byte{
+		"text":   []byte("hello world\nthis is plain text\n"),
+		"html":   []byte("<!DOCTYPE html><html><body>hi</body></html>"),
+		"png":    []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0dIHDR"),
+		"elf":    append([]byte("\x7fELF\x02\x01\x01"), make([]byte, 32)...),
+		"sqlite": append([]byte("SQLite format 3\x00"), make([]byte, 32)...),
+		"pe":     pe,
+		"mz":     []byte("MZ is not always an executable\n"),
+		"bzh":    []byte("BZh this is text\n"),
+		"bzip2":  []byte("BZh91AY&SY\x00\x01"),
+		"empty":  {},
+	}
+	for name, data := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "dir"), 0755))
+
+	testCases := []struct {
+		mimes    []string
+		expected []string
+	}{
+		{[]string{"text/plain"}, []string{"text", "mz", "bzh", "empty"}},
+		{[]string{"text/"}, []string{"text", "html", "mz", "bzh", "empty"}},
+		{[]string{"TEXT/*"}, []string{"text", "html", "mz", "bzh", "empty"}},
+		{[]string{"text/plain; charset=utf-8", "image/png"}, []string{"text", "png", "mz", "bzh", "empty"}},
+		{[]string{"application/x-elf", "application/vnd.microsoft.portable-executable"}, []string{"elf", "pe"}},
+		{[]string{"application/vnd.sqlite3"}, []string{"sqlite"}},
+		{[]string{"application/x-bzip2"}, []string{"bzip2"}},
+		{[]string{}, []string{}},
+	}
+
+	for _, tc := range testCases {
+		m := matches.NewContentMatcher(tc.mimes, 0)
+		matched := make([]string, 0)
+		for name := range files {
+			ok, err := m.Match(filepath.Join(dir, name))
+			require.NoError(t, err)
+			if ok {
+				matched = append(matched, name)
+			}
+		}
+		assert.ElementsMatch(t, tc.expected, matched, tc.mimes)
+
+		ok, err := m.Match(filepath.Join(dir, "dir"))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
+
+func TestContentMatcherSniffBytes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mixed")
+	data := append([]byte("plain text at the start "), 0x00, 0x01, 0x02)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	m := matches.NewContentMatcher([]string{"text/plain"}, 10)
+	ok, err := m.Match(path)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	m = matches.NewContentMatcher([]string{"text/plain"}, 0)
+	ok, err = m.Match(path)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestContentMatcherMissingFile(t *testing.T) {
+	m := matches.NewContentMatcher([]string{"text/plain"}, 0)
+	_, err := m.Match(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+import (
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// EntryMatcher is used to determine if a file system entry matches using more than just its path.
+type EntryMatcher interface {
+	// MatchEntry checks if the entry at path matches and returns true if it does
+	MatchEntry(path string, d fs.DirEntry) (bool, error)
+}
+
+// EntryMatcherFunc is an adapter to allow the use of an ordinary function as an EntryMatcher.
+type EntryMatcherFunc func(path string, d fs.DirEntry) (bool, error)
+
+func (f EntryMatcherFunc) MatchEntry(path string, d fs.DirEntry) (bool, error) {
+	return f(path, d)
+}
+
+//-----------------------------------------------------------------------------
+// SizeMatcher
+
+// SizeMatcher will match entries of which the size in bytes is within a range.
+type SizeMatcher struct {
+	min int64
+	max int64
+}
+
+// Create a new SizeMatcher that matches entries with a size from min up to and including max bytes.
+// A negative max means there is no upper limit.
+func NewSizeMatcher(min int64, max int64) *SizeMatcher {
+	return &SizeMatcher{
+		min: min,
+		max: max,
+	}
+}
+
+func (m *SizeMatcher) MatchEntry(path string, d fs.DirEntry) (bool, error) {
+	info, err := entryInfo(path, d)
+	if err != nil {
+		return false, err
+	}
+
+	size := info.Size()
+	if size < m.min {
+		return false, nil
+	}
+	return m.max < 0 || size <= m.max, nil
+}
+
+//-----------------------------------------------------------------------------
+// ModTimeMatcher
+
+// ModTimeMatcher will match entries of which the modification time is within a window.
+type ModTimeMatcher struct {
+	after  time.Time
+	before time.Time
+}
+
+// Create a new ModTimeMatcher that matches entries modified at or after the after time and before the before time.
+// A zero time means that side of the window is open.
+func NewModTimeMatcher(after time.Time, before time.Time) *ModTimeMatcher {
+	return &ModTimeMatcher{
+		after:  after,
+		before: before,
+	}
+}
+
+// Create a new ModTimeMatcher that matches entries modified within the duration before now.
+func NewModifiedWithinMatcher(d time.Duration) *ModTimeMatcher {
+	return NewModTimeMatcher(time.Now().Add(-d), time.Time{})
+}
+
+func (m *ModTimeMatcher) MatchEntry(path string, d fs.DirEntry) (bool, error) {
+	info, err := entryInfo(path, d)
+	if err != nil {
+		return false, err
+	}
+
+	modTime := info.ModTime()
+	if !m.after.IsZero() && modTime.Before(m.after) {
+		return false, nil
+	}
+	return m.before.IsZero() || modTime.Before(m.before), nil
+}
+
+//-----------------------------------------------------------------------------
+// ModeMatcher
+
+// ModeMatcher will match entries based on their file mode bits.
+type ModeMatcher struct {
+	mask fs.FileMode
+	want fs.FileMode
+}
+
+// Create a new ModeMatcher that matches entries whose mode bits selected by mask are equal to want.
+// For example NewModeMatcher(fs.ModeType, fs.ModeSymlink) matches symbolic links and
+// NewModeMatcher(0111, 0111) matches entries that are executable by everyone.
+func NewModeMatcher(mask fs.FileMode, want fs.FileMode) *ModeMatcher {
+	return &ModeMatcher{
+		mask: mask,
+		want: want,
+	}
+}
+
+func (m *ModeMatcher) MatchEntry(path string, d fs.DirEntry) (bool, error) {
+	var mode fs.FileMode
+	if m.mask&^fs.ModeType == 0 {
+		// Only the type bits are needed which is available without calling Info
+		mode = d.Type()
+	} else {
+		info, err := entryInfo(path, d)
+		if err != nil {
+			return false, err
+		}
+		mode = info.Mode()
+	}
+
+	return mode&m.mask == m.want, nil
+}
+
+//-----------------------------------------------------------------------------
+
+func entryInfo(path string, d fs.DirEntry) (fs.FileInfo, error) {
+	info, err := d.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the file info for %q. %w", path, err)
+	}
+	return info, nil
+}
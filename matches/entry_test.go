@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andrejacobs/go-aj/matches"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeMatcher(t *testing.T) {
+	dir := t.TempDir()
+	small := entryForFile(t, dir, "small", 10, 0644)
+	big := entryForFile(t, dir, "big", 100, 0644)
+
+	m := matches.NewSizeMatcher(10, 50)
+	assertMatchEntry(t, true, m, small)
+	assertMatchEntry(t, false, m, big)
+
+	m = matches.NewSizeMatcher(11, -1)
+	assertMatchEntry(t, false, m, small)
+	assertMatchEntry(t, true, m, big)
+
+	m = matches.NewSizeMatcher(0, 100)
+	assertMatchEntry(t, true, m, small)
+	assertMatchEntry(t, true, m, big)
+}
+
+func TestModTimeMatcher(t *testing.T) {
+	dir := t.TempDir()
+	old := entryForFile(t, dir, "old", 1, 0644)
+	recent := entryForFile(t, dir, "recent", 1, 0644)
+
+	now := time.Now()
+	oldTime := now.Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(old.path, oldTime, oldTime))
+
+	m := matches.NewModifiedWithinMatcher(24 * time.Hour)
+	assertMatchEntry(t, false, m, old)
+	assertMatchEntry(t, true, m, recent)
+
+	m = matches.NewModTimeMatcher(time.Time{}, now.Add(-24*time.Hour))
+	assertMatchEntry(t, true, m, old)
+	assertMatchEntry(t, false, m, recent)
+
+	m = matches.NewModTimeMatcher(oldTime, oldTime.Add(time.Second))
+	assertMatchEntry(t, true, m, old)
+	assertMatchEntry(t, false, m, recent)
+
+	m = matches.NewModTimeMatcher(time.Time{}, time.Time{})
+	assertMatchEntry(t, true, m, old)
+	assertMatchEntry(t, true, m, recent)
+}
+
+func TestModeMatcher(t *testing.T) {
+	dir := t.TempDir()
+	plain := entryForFile(t, dir, "plain", 1, 0644)
+	exec := entryForFile(t, dir, "exec", 1, 0755)
+	require.NoError(t, os.Chmod(exec.path, 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	sub := entryFor(t, dir, "sub")
+
+	m := matches.NewModeMatcher(fs.ModeType, fs.ModeDir)
+	assertMatchEntry(t, false, m, plain)
+	assertMatchEntry(t, true, m, sub)
+
+	m = matches.NewModeMatcher(fs.ModeType, 0)
+	assertMatchEntry(t, true, m, plain)
+	assertMatchEntry(t, false, m, sub)
+
+	m = matches.NewModeMatcher(fs.ModeType|0100, 0100)
+	assertMatchEntry(t, false, m, plain)
+	assertMatchEntry(t, true, m, exec)
+	assertMatchEntry(t, false, m, sub)
+}
+
+func TestEntryMatcherFunc(t *testing.T) {
+	dir := t.TempDir()
+	e := entryForFile(t, dir, "a", 1, 0644)
+
+	var m matches.EntryMatcher = matches.EntryMatcherFunc(func(path string, d fs.DirEntry) (bool, error) {
+		return d.Name() == "a", nil
+	})
+	assertMatchEntry(t, true, m, e)
+}
+
+//-----------------------------------------------------------------------------
+
+type testEntry struct {
+	path string
+	d    fs.DirEntry
+}
+
+func entryForFile(t *testing.T, dir string, name string, size int, perm fs.FileMode) testEntry {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), make([]byte, size), perm))
+	return entryFor(t, dir, name)
+}
+
+func entryFor(t *testing.T, dir string, name string) testEntry {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, d := range entries {
+		if d.Name() == name {
+			return testEntry{path: filepath.Join(dir, name), d: d}
+		}
+	}
+	require.FailNow(t, "entry not found", name)
+	return testEntry{}
+}
+
+func assertMatchEntry(t *testing.T, expected bool, m matches.EntryMatcher, e testEntry) {
+	t.Helper()
+	matched, err := m.MatchEntry(e.path, e.d)
+	require.NoError(t, err)
+	assert.Equal(t, expected, matched, e.path)
+}
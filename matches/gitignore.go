@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GitignoreMatcher will match a file system path against a set of patterns using the same rules as
+// a .gitignore file. See https://git-scm.com/docs/gitignore for details.
+//
+//   - Blank lines and lines starting with '#' are ignored.
+//   - A leading '!' negates the pattern, re-including a path excluded by a previous pattern.
+//   - A trailing '/' only matches directories.
+//   - A pattern with a '/' at the start or in the middle is relative to the root, otherwise it matches at any level.
+//   - '**' matches zero or more directories.
+//
+// The last pattern that matches a path decides the outcome and a path is also matched when any of its
+// parent directories are matched, since git does not look inside an ignored directory.
+// Paths are expected to be relative to the directory containing the .gitignore file.
+type GitignoreMatcher struct {
+	rules []gitignoreRule
+}
+
+type gitignoreRule struct {
+	regex   *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Create a new GitignoreMatcher using the gitignore patterns.
+func NewGitignoreMatcher(patterns []string) (*GitignoreMatcher, error) {
+	g := &GitignoreMatcher{}
+	for i, p := range patterns {
+		if err := g.add(p); err != nil {
+			return nil, fmt.Errorf("failed to create the GitignoreMatcher. the pattern at index [%d] %q is not valid. %w", i, p, err)
+		}
+	}
+	return g, nil
+}
+
+// Create a new GitignoreMatcher using the patterns read line by line from the io.Reader, e.g. a .gitignore file.
+func NewGitignoreMatcherFromReader(rd io.Reader) (*GitignoreMatcher, error) {
+	var patterns []string
+	scanner := bufio.NewScanner(rd)
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the gitignore patterns. %w", err)
+	}
+	return NewGitignoreMatcher(patterns)
+}
+
+// Match checks if the path is ignored and returns true if it is.
+// A path ending with a '/' is treated as a directory.
+func (g *GitignoreMatcher) Match(path string) (bool, error) {
+	path = filepath.ToSlash(path)
+	isDir := strings.HasSuffix(path, "/")
+	return g.MatchPath(path, isDir), nil
+}
+
+// MatchPath checks if the path is ignored and returns true if it is.
+// isDir specifies if the path is a directory.
+func (g *GitignoreMatcher) MatchPath(path string, isDir bool) bool {
+	path = strings.Trim(filepath.ToSlash(path), "/")
+	path = strings.TrimPrefix(path, "./")
+	if path == "" || path == "." {
+		return false
+	}
+
+	// A path inside an ignored directory is ignored
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && g.matchSingle(path[:i], true) {
+			return true
+		}
+	}
+
+	return g.matchSingle(path, isDir)
+}
+
+// Apply the rules to a single path without considering the parent directories.
+func (g *GitignoreMatcher) matchSingle(path string, isDir bool) bool {
+	for i := len(g.rules) - 1; i >= 0; i-- {
+		rule := g.rules[i]
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.regex.MatchString(path) {
+			return !rule.negate
+		}
+	}
+	return false
+}
+
+func (g *GitignoreMatcher) add(pattern string) error {
+	// Trailing spaces are ignored unless escaped
+	pattern = strings.TrimSuffix(pattern, "\r")
+	trimmed := strings.TrimRight(pattern, " ")
+	if len(trimmed) < len(pattern) && strings.HasSuffix(trimmed, "\\") {
+		trimmed += " "
+	}
+	pattern = trimmed
+
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return nil
+	}
+
+	rule := gitignoreRule{}
+	if strings.HasPrefix(pattern, "!") {
+		rule.negate = true
+		pattern = pattern[1:]
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		rule.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return nil
+	}
+
+	expr, err := globToRegex(pattern, false)
+	if err != nil {
+		return err
+	}
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+
+	rule.regex, err = regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return err
+	}
+
+	g.rules = append(g.rules, rule)
+	return nil
+}
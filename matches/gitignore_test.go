@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/matches"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitignoreMatcher(t *testing.T) {
+	g, err := matches.NewGitignoreMatcher([]string{
+		"# comment",
+		"",
+		"*.log",
+		"!important.log",
+		"build/",
+		"/root-only.txt",
+		"docs/*.md",
+		"**/cache",
+		"assets/**/*.png",
+		"vendor/**",
+		"\\#hash",
+		"\\!bang",
+		"trailing   ",
+		"escaped\\ ",
+		"file[0-9].txt",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{"debug.log", false, true},
+		{"sub/dir/debug.log", false, true},
+		{"important.log", false, false},
+		{"sub/important.log", false, false},
+
+		// Directory only
+		{"build", true, true},
+		{"build", false, false},
+		{"sub/build", true, true},
+		{"build/output.bin", false, true},
+
+		// Anchored
+		{"root-only.txt", false, true},
+		{"sub/root-only.txt", false, false},
+		{"docs/readme.md", false, true},
+		{"docs/sub/readme.md", false, false},
+		{"other/docs/readme.md", false, false},
+
+		// Double asterisk
+		{"cache", true, true},
+		{"a/b/cache", false, true},
+		{"a/b/cache/file", false, true},
+		{"assets/logo.png", false, true},
+		{"assets/a/b/logo.png", false, true},
+		{"assets/a/b/logo.jpg", false, false},
+		{"vendor/pkg/file.go", false, true},
+		{"vendor", true, false},
+
+		// Escapes and whitespace
+		{"#hash", false, true},
+		{"!bang", false, true},
+		{"trailing", false, true},
+		{"escaped ", false, true},
+		{"escaped", false, false},
+
+		// Character classes
+		{"file1.txt", false, true},
+		{"filex.txt", false, false},
+
+		{"main.go", false, false},
+		{"", true, false},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, g.MatchPath(tc.path, tc.isDir), "%q isDir: %v", tc.path, tc.isDir)
+	}
+
+	// Trailing slash means directory
+	m, err := g.Match("build/")
+	require.NoError(t, err)
+	assert.True(t, m)
+	m, err = g.Match("build")
+	require.NoError(t, err)
+	assert.False(t, m)
+	m, err = g.Match("./sub/debug.log")
+	require.NoError(t, err)
+	assert.True(t, m)
+}
+
+func TestGitignoreMatcherNonASCII(t *testing.T) {
+	g, err := matches.NewGitignoreMatcher([]string{"naïve/", "café.md", "[é]x", "\\ü*", "日本/*.txt"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"naïve/x", true},
+		{"naive/x", false},
+		{"docs/café.md", true},
+		{"docs/cafe.md", false},
+		{"éx", true},
+		{"ex", false},
+		{"über", true},
+		{"日本/readme.txt", true},
+		{"日本/readme.md", false},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, g.MatchPath(tc.path, false), tc.path)
+	}
+}
+
+func TestGitignoreMatcherParentExcluded(t *testing.T) {
+	// A file can't be re-included when its parent directory is excluded
+	g, err := matches.NewGitignoreMatcher([]string{"logs/", "!logs/keep.log"})
+	require.NoError(t, err)
+	assert.True(t, g.MatchPath("logs/keep.log", false))
+
+	// But it can when only the contents are excluded
+	g, err = matches.NewGitignoreMatcher([]string{"logs/*", "!logs/keep.log"})
+	require.NoError(t, err)
+	assert.False(t, g.MatchPath("logs/keep.log", false))
+	assert.True(t, g.MatchPath("logs/other.log", false))
+}
+
+func TestGitignoreMatcherFromReader(t *testing.T) {
+	g, err := matches.NewGitignoreMatcherFromReader(strings.NewReader("# Go\n*.test\r\n/bin/\n"))
+	require.NoError(t, err)
+	assert.True(t, g.MatchPath("pkg.test", false))
+	assert.True(t, g.MatchPath("bin", true))
+	assert.False(t, g.MatchPath("cmd/bin", true))
+}
+
+func TestGitignoreMatcherInvalid(t *testing.T) {
+	_, err := matches.NewGitignoreMatcher([]string{"file[0-9"})
+	assert.ErrorContains(t, err, "unterminated character class")
+
+	_, err = matches.NewGitignoreMatcher([]string{"file\\"})
+	assert.ErrorContains(t, err, "escape character")
+}
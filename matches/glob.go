@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Translate a glob pattern into the equivalent regular expression (without anchors) where paths use / as the separator.
+//
+//   - '*' matches any sequence of characters except /
+//   - '?' matches any single character except /
+//   - '[...]' matches a character class, negated by a leading '!' or '^' (which never matches /)
+//   - '**' as a whole path segment matches zero or more directories
+//   - '{a,b}' matches any of the comma separated alternatives when braces is true, which can be nested
+//   - '\' escapes the next character
+func globToRegex(pattern string, braces bool) (string, error) {
+	var sb strings.Builder
+
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case braces && c == '{':
+			depth++
+			sb.WriteString("(?:")
+
+		case braces && c == ',' && depth > 0:
+			sb.WriteByte('|')
+
+		case braces && c == '}' && depth > 0:
+			depth--
+			sb.WriteByte(')')
+
+		case c == '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				// '**' is only special as a whole path segment
+				startOfSegment := i == 0 || pattern[i-1] == '/'
+				end := i + 2
+				if startOfSegment && end == len(pattern) {
+					sb.WriteString(".*")
+					i++
+					continue
+				}
+				if startOfSegment && pattern[end] == '/' {
+					sb.WriteString("(?:.*/)?")
+					i += 2
+					continue
+				}
+				// Consecutive asterisks are treated as a single asterisk
+				for i+1 < len(pattern) && pattern[i+1] == '*' {
+					i++
+				}
+			}
+			sb.WriteString("[^/]*")
+
+		case c == '?':
+			sb.WriteString("[^/]")
+
+		case c == '[':
+			class, n, err := globClass(pattern[i:])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(class)
+			i += n - 1
+
+		case c == '\\':
+			if i+1 == len(pattern) {
+				return "", fmt.Errorf("the pattern %q ends with an escape character", pattern)
+			}
+			i++
+			i += writeLiteral(&sb, pattern[i:]) - 1
+
+		default:
+			i += writeLiteral(&sb, pattern[i:]) - 1
+		}
+	}
+
+	if depth > 0 {
+		return "", fmt.Errorf("the pattern %q has an unterminated brace expansion", pattern)
+	}
+
+	return sb.String(), nil
+}
+
+// Translate the character class at the start of the pattern and return the number of bytes consumed.
+func globClass(pattern string) (string, int, error) {
+	var sb strings.Builder
+	sb.WriteByte('[')
+
+	i := 1
+	negated := i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^')
+	if negated {
+		sb.WriteByte('^')
+		i++
+	}
+
+	first := true
+	for ; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == ']' && !first:
+			if negated {
+				// Stay within the path segment
+				sb.WriteByte('/')
+			}
+			sb.WriteByte(']')
+			return sb.String(), i + 1, nil
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			i += writeLiteral(&sb, pattern[i:]) - 1
+		case c == '-':
+			sb.WriteByte('-')
+		case c == '[' || c == ']' || c == '^':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			i += writeLiteral(&sb, pattern[i:]) - 1
+		}
+		first = false
+	}
+
+	return "", 0, fmt.Errorf("the pattern %q has an unterminated character class", pattern)
+}
+
+// Write the character (which can be a multi-byte UTF-8 sequence) at the start of s as a literal
+// and return the number of bytes consumed.
+func writeLiteral(sb *strings.Builder, s string) int {
+	_, size := utf8.DecodeRuneInString(s)
+	sb.WriteString(regexp.QuoteMeta(s[:size]))
+	return size
+}
+
+// Compile the glob pattern (with brace expansion) into a regular expression that matches the whole path.
+func compileGlob(pattern string, o options) (*regexp.Regexp, error) {
+	expr, err := globToRegex(pattern, true)
+	if err != nil {
+		return nil, err
+	}
+	if o.caseInsensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile("^" + expr + "$")
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KVScanner is used to read structured records from an io.Reader line by line and then
+// tries to match the records against a set of conditions.
+// Each line is either a JSON object (JSON lines) or a sequence of key=value pairs (logfmt), where
+// values containing spaces are quoted, e.g. `level=error msg="disk full" code=28`.
+// Lines that can't be parsed as a record are skipped.
+type KVScanner struct {
+	entries       []kvScannerEntry
+	maxLineLength int
+}
+
+// KVRecord is a parsed line as a map of the field names to their values.
+// JSON values that are not strings are kept as their JSON text, e.g. `42`, `true` or `{"a":1}`.
+type KVRecord map[string]string
+
+// Function that will be called when a record matched all of the conditions.
+type KVScannerFoundRecord func(key string, record KVRecord, lineNumber int) error
+
+// Result from the Process function. A map of the key to the matching record.
+// NOTE: The result will always contain the last found record for a key (meaning the map is updated on each find).
+type KVScannerResult map[string]KVRecord
+
+// Register the conditions that will try and find matching records when the Process function is called.
+// conditions is a map of the field names to a regular expression that the value of the field needs to match.
+// A record matches when it has all of the fields and all of their values match, no conditions matches every record.
+func (s *KVScanner) Add(key string, conditions map[string]string, foundFn KVScannerFoundRecord) error {
+	fields := make([]string, 0, len(conditions))
+	for field := range conditions {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	entry := kvScannerEntry{
+		key:     key,
+		foundFn: foundFn,
+	}
+
+	for _, field := range fields {
+		expression := conditions[field]
+		regex, err := regexp.Compile(expression)
+		if err != nil {
+			return fmt.Errorf("failed to compile the regular expression for the key: %q field: %q expression: %q. %w", key, field, expression, err)
+		}
+		entry.conditions = append(entry.conditions, kvCondition{field: field, regex: regex})
+	}
+
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+// Set the maximum length in bytes of a line that can be read by the Process method.
+// Reading a longer line will fail with bufio.ErrTooLong. The default is bufio.MaxScanTokenSize (64 KiB).
+func (s *KVScanner) SetMaxLineLength(n int) {
+	s.maxLineLength = n
+}
+
+// Read line by line from the io.Reader, parse the records and try and find the ones matching the conditions.
+func (s *KVScanner) Process(rd io.Reader) (KVScannerResult, error) {
+	return s.ProcessContext(context.Background(), rd)
+}
+
+// Read line by line from the io.Reader, parse the records and try and find the ones matching the conditions.
+// The context is checked before each line is processed and the cause of the cancellation is returned
+// along with the results found so far. A Read call that blocks on the io.Reader is not interrupted.
+func (s *KVScanner) ProcessContext(ctx context.Context, rd io.Reader) (KVScannerResult, error) {
+	scanner := bufio.NewScanner(rd)
+	if s.maxLineLength > 0 {
+		scanner.Buffer(make([]byte, 0, min(s.maxLineLength, bufio.MaxScanTokenSize)), s.maxLineLength)
+	}
+	result := make(KVScannerResult)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return result, context.Cause(ctx)
+		}
+
+		record, ok := ParseKVRecord(scanner.Text())
+		if ok {
+			for _, entry := range s.entries {
+				if !entry.matches(record) {
+					continue
+				}
+
+				result[entry.key] = record
+				if entry.foundFn != nil {
+					if err := entry.foundFn(entry.key, record, lineNumber); err != nil {
+						return result, err
+					}
+				}
+			}
+		}
+		lineNumber++
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read line %d. %w", lineNumber, err)
+	}
+
+	return result, nil
+}
+
+// ParseKVRecord parses a line that is either a JSON object or a sequence of key=value pairs.
+// Returns false if the line is empty or not a valid record, a line without any key=value pairs is not a record.
+func ParseKVRecord(line string) (KVRecord, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil, false
+	}
+
+	if line[0] == '{' {
+		return parseJSONRecord(line)
+	}
+	return parseLogfmtRecord(line)
+}
+
+//-----------------------------------------------------------------------------
+
+type kvScannerEntry struct {
+	key        string
+	conditions []kvCondition
+	foundFn    KVScannerFoundRecord
+}
+
+type kvCondition struct {
+	field string
+	regex *regexp.Regexp
+}
+
+func (e *kvScannerEntry) matches(record KVRecord) bool {
+	for _, c := range e.conditions {
+		value, ok := record[c.field]
+		if !ok || !c.regex.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseJSONRecord(line string) (KVRecord, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return nil, false
+	}
+
+	record := make(KVRecord, len(fields))
+	for k, raw := range fields {
+		var str string
+		if len(raw) > 0 && raw[0] == '"' && json.Unmarshal(raw, &str) == nil {
+			record[k] = str
+			continue
+		}
+
+		var compact bytes.Buffer
+		if json.Compact(&compact, raw) == nil {
+			record[k] = compact.String()
+		} else {
+			record[k] = string(raw)
+		}
+	}
+	return record, true
+}
+
+func parseLogfmtRecord(line string) (KVRecord, bool) {
+	record := make(KVRecord)
+	pairs := 0
+	i := 0
+	for i < len(line) {
+		// Skip the whitespace between pairs
+		for i < len(line) && isLogfmtSpace(line[i]) {
+			i++
+		}
+		if i >= len(line) {
+			break
+		}
+
+		start := i
+		for i < len(line) && line[i] != '=' && !isLogfmtSpace(line[i]) {
+			i++
+		}
+		key := line[start:i]
+		if key == "" {
+			return nil, false
+		}
+
+		if i >= len(line) || line[i] != '=' {
+			// A key without a value
+			record[key] = ""
+			continue
+		}
+		i++ // '='
+		pairs++
+
+		if i < len(line) && line[i] == '"' {
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				// Unterminated quoted value
+				return nil, false
+			}
+
+			value, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, false
+			}
+			record[key] = value
+			i = end + 1
+			continue
+		}
+
+		start = i
+		for i < len(line) && !isLogfmtSpace(line[i]) {
+			i++
+		}
+		record[key] = line[start:i]
+	}
+
+	// Plain text is not a record
+	if pairs == 0 {
+		return nil, false
+	}
+	return record, true
+}
+
+func isLogfmtSpace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
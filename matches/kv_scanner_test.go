@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches_test
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/matches"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKVRecord(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected matches.KVRecord
+	}{
+		{`level=info msg=started`, matches.KVRecord{"level": "info", "msg": "started"}},
+		{`  level=error msg="disk full" code=28 retry`, matches.KVRecord{"level": "error", "msg": "disk full", "code": "28", "retry": ""}},
+		{`msg="say \"hi\"\tnow" empty= x=1`, matches.KVRecord{"msg": "say \"hi\"\tnow", "empty": "", "x": "1"}},
+		{`url=http://x/?a=b`, matches.KVRecord{"url": "http://x/?a=b"}},
+		{`{"level":"warn","code":42,"ok":true,"nested":{"a": [1, 2]},"none":null}`,
+			matches.KVRecord{"level": "warn", "code": "42", "ok": "true", "nested": `{"a":[1,2]}`, "none": "null"}},
+		{``, nil},
+		{`   `, nil},
+		{`just some text`, nil},
+		{`msg="unterminated`, nil},
+		{`=value`, nil},
+		{`{"broken": `, nil},
+	}
+
+	for _, tc := range tests {
+		record, ok := matches.ParseKVRecord(tc.line)
+		assert.Equal(t, tc.expected != nil, ok, tc.line)
+		assert.Equal(t, tc.expected, record, tc.line)
+	}
+}
+
+func TestKVScanner(t *testing.T) {
+	input := `level=info msg="starting up" port=8080
+this line is not structured
+{"level":"error","msg":"disk full","path":"/var/log"}
+level=error msg="connection refused" host=db1
+{"level":"info","msg":"done"}
+`
+
+	type found struct {
+		key        string
+		lineNumber int
+		msg        string
+	}
+	var calls []found
+	foundFn := func(key string, record matches.KVRecord, lineNumber int) error {
+		calls = append(calls, found{key: key, lineNumber: lineNumber, msg: record["msg"]})
+		return nil
+	}
+
+	s := &matches.KVScanner{}
+	require.NoError(t, s.Add("errors", map[string]string{"level": "^error$"}, foundFn))
+	require.NoError(t, s.Add("db", map[string]string{"level": "error", "host": `^db\d+$`}, foundFn))
+	require.NoError(t, s.Add("all", nil, nil))
+
+	result, err := s.Process(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, []found{
+		{"errors", 2, "disk full"},
+		{"errors", 3, "connection refused"},
+		{"db", 3, "connection refused"},
+	}, calls)
+
+	assert.Equal(t, "connection refused", result["errors"]["msg"])
+	assert.Equal(t, "db1", result["db"]["host"])
+	assert.Equal(t, matches.KVRecord{"level": "info", "msg": "done"}, result["all"])
+}
+
+func TestKVScannerFailToCompile(t *testing.T) {
+	s := &matches.KVScanner{}
+	err := s.Add("bad", map[string]string{"level": "("}, nil)
+	assert.ErrorContains(t, err, `field: "level"`)
+}
+
+func TestKVScannerContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	s := &matches.KVScanner{}
+	s.Add("all", nil, func(key string, record matches.KVRecord, lineNumber int) error {
+		count++
+		if count == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	_, err := s.ProcessContext(ctx, strings.NewReader(strings.Repeat("a=1\n", 10)))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, count)
+}
+
+func TestKVScannerMaxLineLength(t *testing.T) {
+	input := "a=1\nmsg=" + strings.Repeat("x", 100*1024) + "\n"
+
+	s := &matches.KVScanner{}
+	s.Add("all", nil, nil)
+
+	_, err := s.Process(strings.NewReader(input))
+	assert.ErrorIs(t, err, bufio.ErrTooLong)
+
+	s.SetMaxLineLength(200 * 1024)
+	result, err := s.Process(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Len(t, result["all"]["msg"], 100*1024)
+}
@@ -0,0 +1,305 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"unicode/utf8"
+)
+
+// Only lists with at least this many expressions are optimized since it is not worth it for a few.
+const optimizeThreshold = 16
+
+// literalSet speeds up checking if any of a large number of regular expressions match by handling the
+// expressions that are plain literals (optionally anchored to the start and/or end) without regexp.
+// Expressions that are not literals are kept and matched one by one.
+type literalSet struct {
+	contains *ahoCorasick        // unanchored literals
+	exact    map[string]struct{} // literals anchored to the start and end
+	prefixes anchoredLiterals    // literals anchored to the start
+	suffixes anchoredLiterals    // literals anchored to the end
+	rest     []*regexp.Regexp    // expressions that are not literals
+}
+
+// Create a literalSet from the compiled expressions. Returns nil if the expressions could not be optimized.
+func newLiteralSet(compiled []*regexp.Regexp) *literalSet {
+	s := &literalSet{
+		exact: make(map[string]struct{}),
+	}
+
+	var contains []string
+	for _, re := range compiled {
+		lit, kind := classifyLiteral(re.String())
+		switch kind {
+		case literalContains:
+			contains = append(contains, lit)
+		case literalExact:
+			s.exact[lit] = struct{}{}
+		case literalPrefix:
+			s.prefixes.add(lit)
+		case literalSuffix:
+			s.suffixes.add(lit)
+		default:
+			s.rest = append(s.rest, re)
+		}
+	}
+
+	if len(s.rest) == len(compiled) {
+		return nil
+	}
+
+	if len(contains) > 0 {
+		s.contains = newAhoCorasick(contains)
+	}
+	return s
+}
+
+func (s *literalSet) matchesAny(needle string) bool {
+	if _, ok := s.exact[needle]; ok {
+		return true
+	}
+	if s.prefixes.matchPrefix(needle) || s.suffixes.matchSuffix(needle) {
+		return true
+	}
+	if s.contains != nil && s.contains.matchesAny(needle) {
+		return true
+	}
+	return matchesAnyRegexp(s.rest, needle)
+}
+
+//-----------------------------------------------------------------------------
+
+type literalKind int
+
+const (
+	notLiteral literalKind = iota
+	literalContains
+	literalExact
+	literalPrefix
+	literalSuffix
+)
+
+// Determine if the expression is a literal string and how it is anchored.
+func classifyLiteral(expr string) (string, literalKind) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return "", notLiteral
+	}
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		if lit, ok := literalString(re); ok {
+			return lit, literalContains
+		}
+	case syntax.OpConcat:
+		subs := re.Sub
+		begin := len(subs) > 0 && subs[0].Op == syntax.OpBeginText
+		if begin {
+			subs = subs[1:]
+		}
+		end := len(subs) > 0 && subs[len(subs)-1].Op == syntax.OpEndText
+		if end {
+			subs = subs[:len(subs)-1]
+		}
+		if len(subs) != 1 || subs[0].Op != syntax.OpLiteral {
+			break
+		}
+
+		lit, ok := literalString(subs[0])
+		if !ok {
+			break
+		}
+		switch {
+		case begin && end:
+			return lit, literalExact
+		case begin:
+			return lit, literalPrefix
+		case end:
+			return lit, literalSuffix
+		}
+	}
+
+	return "", notLiteral
+}
+
+// Return the literal as a string unless it needs to be matched case-insensitive or it contains
+// the replacement character (which regexp also matches against invalid UTF-8).
+func literalString(re *syntax.Regexp) (string, bool) {
+	if re.Flags&syntax.FoldCase != 0 || slices.Contains(re.Rune, utf8.RuneError) {
+		return "", false
+	}
+	return string(re.Rune), true
+}
+
+//-----------------------------------------------------------------------------
+
+// Literals grouped by length so that checking a needle only needs a lookup per distinct length.
+type anchoredLiterals struct {
+	literals map[string]struct{}
+	lengths  []int
+}
+
+func (a *anchoredLiterals) add(lit string) {
+	if a.literals == nil {
+		a.literals = make(map[string]struct{})
+	}
+	a.literals[lit] = struct{}{}
+	if !slices.Contains(a.lengths, len(lit)) {
+		a.lengths = append(a.lengths, len(lit))
+	}
+}
+
+func (a *anchoredLiterals) matchPrefix(needle string) bool {
+	for _, n := range a.lengths {
+		if n <= len(needle) {
+			if _, ok := a.literals[needle[:n]]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *anchoredLiterals) matchSuffix(needle string) bool {
+	for _, n := range a.lengths {
+		if n <= len(needle) {
+			if _, ok := a.literals[needle[len(needle)-n:]]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//-----------------------------------------------------------------------------
+
+// ahoCorasick finds if any of a set of strings occur within a needle in a single pass.
+// See https://en.wikipedia.org/wiki/Aho%E2%80%93Corasick_algorithm
+type ahoCorasick struct {
+	edges  map[uint64]int32 // (node << 8 | byte) to the next node
+	fail   []int32          // node to follow when there is no edge for the next byte
+	output []bool           // a string ends at the node or at one of its fail nodes
+	root   []int32          // the edges from the root node for each byte
+}
+
+func newAhoCorasick(literals []string) *ahoCorasick {
+	ac := &ahoCorasick{
+		edges:  make(map[uint64]int32),
+		fail:   []int32{0},
+		output: []bool{false},
+		root:   make([]int32, 256),
+	}
+
+	// Build the trie
+	for _, lit := range literals {
+		node := int32(0)
+		for i := 0; i < len(lit); i++ {
+			next, ok := ac.edge(node, lit[i])
+			if !ok {
+				next = int32(len(ac.fail))
+				ac.fail = append(ac.fail, 0)
+				ac.output = append(ac.output, false)
+				ac.setEdge(node, lit[i], next)
+			}
+			node = next
+		}
+		ac.output[node] = true
+	}
+
+	// Link each node to the longest proper suffix that is also in the trie (breadth first)
+	queue := make([]int32, 0, len(ac.fail))
+	for b := range 256 {
+		if next := ac.root[b]; next != 0 {
+			queue = append(queue, next)
+		}
+	}
+
+	children := make(map[int32][]byteEdge)
+	for key, next := range ac.edges {
+		node := int32(key >> 8)
+		children[node] = append(children[node], byteEdge{b: byte(key), next: next})
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, e := range children[node] {
+			f := ac.fail[node]
+			for {
+				if next, ok := ac.edge(f, e.b); ok {
+					ac.fail[e.next] = next
+					break
+				}
+				if f == 0 {
+					break
+				}
+				f = ac.fail[f]
+			}
+			ac.output[e.next] = ac.output[e.next] || ac.output[ac.fail[e.next]]
+			queue = append(queue, e.next)
+		}
+	}
+
+	return ac
+}
+
+type byteEdge struct {
+	b    byte
+	next int32
+}
+
+func (ac *ahoCorasick) edge(node int32, b byte) (int32, bool) {
+	if node == 0 {
+		next := ac.root[b]
+		return next, next != 0
+	}
+	next, ok := ac.edges[uint64(node)<<8|uint64(b)]
+	return next, ok
+}
+
+func (ac *ahoCorasick) setEdge(node int32, b byte, next int32) {
+	if node == 0 {
+		ac.root[b] = next
+	}
+	ac.edges[uint64(node)<<8|uint64(b)] = next
+}
+
+func (ac *ahoCorasick) matchesAny(needle string) bool {
+	node := int32(0)
+	for i := 0; i < len(needle); i++ {
+		for {
+			if next, ok := ac.edge(node, needle[i]); ok {
+				node = next
+				break
+			}
+			if node == 0 {
+				break
+			}
+			node = ac.fail[node]
+		}
+		if ac.output[node] {
+			return true
+		}
+	}
+	return false
+}
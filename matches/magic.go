@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// MagicSignature identifies a file format by the bytes found at an offset from the start of the file.
+type MagicSignature struct {
+	Name   string                 // Name of the format, e.g. "PNG image"
+	MIME   string                 // MIME type of the format, can be empty
+	Offset int                    // Offset in bytes from the start of the file where Magic is expected
+	Magic  []byte                 // The bytes that need to be found at Offset
+	Verify func(data []byte) bool // Optional extra check for signatures that are too short on their own
+}
+
+// Match checks if data (read from the start of a file) contains the signature.
+func (s MagicSignature) Match(data []byte) bool {
+	end := s.Offset + len(s.Magic)
+	if s.Offset < 0 || len(data) < end || !bytes.Equal(data[s.Offset:end], s.Magic) {
+		return false
+	}
+	return s.Verify == nil || s.Verify(data)
+}
+
+// MagicSignatures is a built-in table of signatures for common file formats.
+var MagicSignatures = []MagicSignature{
+	{"ELF executable", "application/x-elf", 0, []byte("\x7fELF"), nil},
+	{"Mach-O executable", "application/x-mach-binary", 0, []byte("\xfe\xed\xfa\xce"), nil},
+	{"Mach-O executable", "application/x-mach-binary", 0, []byte("\xfe\xed\xfa\xcf"), nil},
+	{"Mach-O executable", "application/x-mach-binary", 0, []byte("\xce\xfa\xed\xfe"), nil},
+	{"Mach-O executable", "application/x-mach-binary", 0, []byte("\xcf\xfa\xed\xfe"), nil},
+	{"Mach-O universal binary", "application/x-mach-binary", 0, []byte("\xca\xfe\xba\xbe"), nil}, // shares the magic with Java classes
+	{"PE executable", "application/vnd.microsoft.portable-executable", 0, []byte("MZ"), isPortableExecutable},
+	{"WebAssembly module", "application/wasm", 0, []byte("\x00asm"), nil},
+	{"SQLite database", "application/vnd.sqlite3", 0, []byte("SQLite format 3\x00"), nil},
+	{"PNG image", "image/png", 0, []byte("\x89PNG\r\n\x1a\n"), nil},
+	{"JPEG image", "image/jpeg", 0, []byte("\xff\xd8\xff"), nil},
+	{"GIF image", "image/gif", 0, []byte("GIF87a"), nil},
+	{"GIF image", "image/gif", 0, []byte("GIF89a"), nil},
+	{"PDF document", "application/pdf", 0, []byte("%PDF-"), nil},
+	{"ZIP archive", "application/zip", 0, []byte("PK\x03\x04"), nil},
+	{"gzip archive", "application/x-gzip", 0, []byte("\x1f\x8b\x08"), nil},
+	{"bzip2 archive", "application/x-bzip2", 0, []byte("BZh"), isBzip2},
+	{"xz archive", "application/x-xz", 0, []byte("\xfd7zXZ\x00"), nil},
+	{"zstd archive", "application/zstd", 0, []byte("\x28\xb5\x2f\xfd"), nil},
+	{"7z archive", "application/x-7z-compressed", 0, []byte("7z\xbc\xaf\x27\x1c"), nil},
+	{"tar archive", "application/x-tar", 257, []byte("ustar"), nil},
+}
+
+//-----------------------------------------------------------------------------
+// MagicMatcher
+
+// MagicMatcher will match a file system path by the magic numbers found in the content of the file.
+// Paths that are not regular files never match.
+type MagicMatcher struct {
+	signatures []MagicSignature
+	readSize   int
+}
+
+// Create a new MagicMatcher that matches files containing any of the signatures, e.g. MagicSignatures.
+func NewMagicMatcher(signatures []MagicSignature) *MagicMatcher {
+	// Verify functions might need more than just the magic bytes
+	readSize := DefaultSniffBytes
+	for _, s := range signatures {
+		readSize = max(readSize, s.Offset+len(s.Magic))
+	}
+
+	return &MagicMatcher{
+		signatures: signatures,
+		readSize:   readSize,
+	}
+}
+
+func (m *MagicMatcher) Match(path string) (bool, error) {
+	_, matched, err := m.Identify(path)
+	return matched, err
+}
+
+// Identify returns the first signature found in the file at path and true, or false if none matched.
+func (m *MagicMatcher) Identify(path string) (MagicSignature, bool, error) {
+	// Check before opening since opening a named pipe blocks until there is a writer
+	info, err := os.Stat(path)
+	if err != nil {
+		return MagicSignature{}, false, fmt.Errorf("failed to get the file info for %q. %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return MagicSignature{}, false, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return MagicSignature{}, false, fmt.Errorf("failed to open the file %q. %w", path, err)
+	}
+	defer f.Close()
+
+	buffer := make([]byte, m.readSize)
+	n, err := io.ReadFull(f, buffer)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return MagicSignature{}, false, fmt.Errorf("failed to read the file %q. %w", path, err)
+	}
+
+	sig, ok := identifyMagic(m.signatures, buffer[:n])
+	return sig, ok, nil
+}
+
+//-----------------------------------------------------------------------------
+
+func identifyMagic(signatures []MagicSignature, data []byte) (MagicSignature, bool) {
+	for _, sig := range signatures {
+		if sig.Match(data) {
+			return sig, true
+		}
+	}
+	return MagicSignature{}, false
+}
+
+// Check that the DOS header points to a PE header, "MZ" on its own is too likely to start a text file.
+func isPortableExecutable(data []byte) bool {
+	const peOffsetPos = 0x3c
+	if len(data) < peOffsetPos+4 {
+		return false
+	}
+
+	peOffset := int(binary.LittleEndian.Uint32(data[peOffsetPos:]))
+	if peOffset < 0 || peOffset > len(data)-4 {
+		return false
+	}
+	return bytes.Equal(data[peOffset:peOffset+4], []byte("PE\x00\x00"))
+}
+
+// Check that the block size follows the "BZh" magic.
+func isBzip2(data []byte) bool {
+	return len(data) > 3 && data[3] >= '1' && data[3] <= '9'
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches_test
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/matches"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicMatcherBuiltIn(t *testing.T) {
+	dir := t.TempDir()
+
+	pe := make([]byte, 128)
+	copy(pe, "MZ")
+	binary.LittleEndian.PutUint32(pe[0x3c:], 64)
+	copy(pe[64:], "PE\x00\x00")
+
+	tar := make([]byte, 512)
+	copy(tar[257:], "ustar\x0000")
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{"elf", []byte("\x7fELF\x02\x01\x01\x00"), "ELF executable"},
+		{"pe", pe, "PE executable"},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), "PNG image"},
+		{"pdf", []byte("%PDF-1.7\n"), "PDF document"},
+		{"gzip", []byte("\x1f\x8b\x08\x00\x00\x00"), "gzip archive"},
+		{"tar", tar, "tar archive"},
+		{"text", []byte("MZ is not always an executable\n"), ""},
+		{"short", []byte("\x7fEL"), ""},
+		{"empty", []byte{}, ""},
+	}
+
+	m := matches.NewMagicMatcher(matches.MagicSignatures)
+	for _, tc := range testCases {
+		path := filepath.Join(dir, tc.name)
+		require.NoError(t, os.WriteFile(path, tc.data, 0644))
+
+		sig, ok, err := m.Identify(path)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected != "", ok, tc.name)
+		assert.Equal(t, tc.expected, sig.Name, tc.name)
+
+		matched, err := m.Match(path)
+		require.NoError(t, err)
+		assert.Equal(t, ok, matched, tc.name)
+	}
+
+	matched, err := m.Match(dir)
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	_, err = m.Match(filepath.Join(dir, "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestMagicMatcherCustom(t *testing.T) {
+	dir := t.TempDir()
+
+	// Beyond the default number of bytes read
+	data := make([]byte, 2048)
+	copy(data[1000:], "MAGIC")
+	path := filepath.Join(dir, "custom")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	m := matches.NewMagicMatcher([]matches.MagicSignature{
+		{Name: "custom", Offset: 1000, Magic: []byte("MAGIC")},
+	})
+	sig, ok, err := m.Identify(path)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "custom", sig.Name)
+
+	m = matches.NewMagicMatcher([]matches.MagicSignature{
+		{Name: "verified", Magic: []byte{0}, Verify: func(data []byte) bool { return len(data) > 1 && data[1] == 0 }},
+	})
+	matched, err := m.Match(path)
+	require.NoError(t, err)
+	assert.True(t, matched)
+}
+
+func TestMagicSignatureMatch(t *testing.T) {
+	s := matches.MagicSignature{Offset: 2, Magic: []byte("ab")}
+	assert.True(t, s.Match([]byte("xxab")))
+	assert.True(t, s.Match([]byte("xxabyy")))
+	assert.False(t, s.Match([]byte("xxa")))
+	assert.False(t, s.Match([]byte("abxx")))
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package matches
+
+// Option is used to configure the matchers.
+type Option func(o *options)
+
+type options struct {
+	caseInsensitive bool
+	target          PathMatchTarget
+}
+
+// PathMatchTarget determines which part of a path is matched against the patterns.
+type PathMatchTarget int
+
+const (
+	// Match the full path. This is the default.
+	MatchFullPath PathMatchTarget = iota
+	// Match only the last element of the path, e.g. "*.txt" matches "/a/b/c.txt".
+	MatchBaseName
+	// Match each element of the path, e.g. "node_modules" matches "/a/node_modules/b/c.js".
+	MatchAnySegment
+)
+
+// Match without regard to case, e.g. for paths on case-insensitive file systems like those
+// used by default on macOS and Windows.
+func WithCaseInsensitive() Option {
+	return func(o *options) {
+		o.caseInsensitive = true
+	}
+}
+
+// Match the patterns against the part of the path specified by target.
+// Used by ShellPatternPathMatcher since filepath.Match does not match across path separators.
+func WithMatchTarget(target PathMatchTarget) Option {
+	return func(o *options) {
+		o.target = target
+	}
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
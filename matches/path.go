@@ -22,6 +22,8 @@ package matches
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"strings"
 )
 
 // PathMatcher is used to determine if a file system path matches.
@@ -39,8 +41,8 @@ type RegexPathMatcher struct {
 }
 
 // Create a new RegexPathMatcher using the regular expression patterns.
-func NewRegexPathMatcher(expressions []string) (*RegexPathMatcher, error) {
-	regexList, err := NewRegexList(expressions)
+func NewRegexPathMatcher(expressions []string, opts ...Option) (*RegexPathMatcher, error) {
+	regexList, err := NewRegexList(expressions, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the RegexPathMatcher. %w", err)
 	}
@@ -57,29 +59,71 @@ func (r *RegexPathMatcher) Match(path string) (bool, error) {
 	return matched, nil
 }
 
+// Returns the regular expressions that match the path, e.g. to explain why a path was excluded.
+func (r *RegexPathMatcher) MatchingPatterns(path string) []string {
+	return r.regexList.MatchingPatterns(path)
+}
+
 //-----------------------------------------------------------------------------
 // ShellPatternPathMatcher
 
 // ShellPatternPathMatcher will match a file system path against a set of shell patterns.
 // See https://pkg.go.dev/path/filepath#Match for details.
+// By default the whole path needs to match, use WithMatchTarget to match the base name or each path element instead.
 type ShellPatternPathMatcher struct {
-	patterns []string
+	patterns        []string
+	caseInsensitive bool
+	target          PathMatchTarget
 }
 
 // Create a new ShellPatternPathMatcher using the shell patterns.
-func NewShellPatternPathMatcher(patterns []string) *ShellPatternPathMatcher {
+func NewShellPatternPathMatcher(patterns []string, opts ...Option) *ShellPatternPathMatcher {
+	o := applyOptions(opts)
 	matcher := ShellPatternPathMatcher{
-		patterns: patterns,
+		patterns:        patterns,
+		caseInsensitive: o.caseInsensitive,
+		target:          o.target,
+	}
+
+	if o.caseInsensitive {
+		matcher.patterns = make([]string, len(patterns))
+		for i, p := range patterns {
+			matcher.patterns[i] = strings.ToLower(p)
+		}
 	}
+
 	return &matcher
 }
 
 func (s *ShellPatternPathMatcher) Match(path string) (bool, error) {
-	matched := false
+	if s.caseInsensitive {
+		path = strings.ToLower(path)
+	}
 
+	switch s.target {
+	case MatchBaseName:
+		return s.matchAny(filepath.Base(path))
+	case MatchAnySegment:
+		for _, segment := range strings.Split(filepath.ToSlash(path), "/") {
+			if segment == "" {
+				continue
+			}
+			matched, err := s.matchAny(segment)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	default:
+		return s.matchAny(path)
+	}
+}
+
+func (s *ShellPatternPathMatcher) matchAny(name string) (bool, error) {
+	matched := false
 	for _, pattern := range s.patterns {
 		var err error
-		matched, err = filepath.Match(pattern, path)
+		matched, err = filepath.Match(pattern, name)
 		if err != nil {
 			return matched, err
 		}
@@ -90,3 +134,43 @@ func (s *ShellPatternPathMatcher) Match(path string) (bool, error) {
 
 	return matched, nil
 }
+
+//-----------------------------------------------------------------------------
+// GlobPathMatcher
+
+// GlobPathMatcher will match a file system path against a set of glob patterns.
+// Unlike ShellPatternPathMatcher the patterns can match nested paths.
+//
+//   - '*' matches any sequence of characters except the path separator
+//   - '?' matches any single character except the path separator
+//   - '[...]' matches a character class, negated by a leading '!' or '^'
+//   - '**' as a whole path segment matches zero or more directories, e.g. "src/**/*.go"
+//   - '{a,b}' matches any of the comma separated alternatives, e.g. "*.{jpg,png}"
+//   - '\' escapes the next character
+//
+// The whole path needs to match and paths are compared using '/' as the separator.
+type GlobPathMatcher struct {
+	compiled []*regexp.Regexp
+}
+
+// Create a new GlobPathMatcher using the glob patterns.
+func NewGlobPathMatcher(patterns []string, opts ...Option) (*GlobPathMatcher, error) {
+	o := applyOptions(opts)
+	matcher := GlobPathMatcher{
+		compiled: make([]*regexp.Regexp, 0, len(patterns)),
+	}
+
+	for i, pattern := range patterns {
+		r, err := compileGlob(pattern, o)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the GlobPathMatcher. the pattern at index [%d] %q is not valid. %w", i, pattern, err)
+		}
+		matcher.compiled = append(matcher.compiled, r)
+	}
+
+	return &matcher, nil
+}
+
+func (g *GlobPathMatcher) Match(path string) (bool, error) {
+	return matchesAnyRegexp(g.compiled, filepath.ToSlash(path)), nil
+}
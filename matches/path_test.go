@@ -83,3 +83,163 @@ func TestShellPatternPathMatcher(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, m)
 }
+
+func TestShellPatternPathMatcherTarget(t *testing.T) {
+	tests := []struct {
+		target   matches.PathMatchTarget
+		path     string
+		expected bool
+	}{
+		{matches.MatchFullPath, "c.txt", true},
+		{matches.MatchFullPath, "/a/b/c.txt", false},
+		{matches.MatchFullPath, "/a/node_modules/c.js", false},
+		{matches.MatchBaseName, "c.txt", true},
+		{matches.MatchBaseName, "/a/b/c.txt", true},
+		{matches.MatchBaseName, "/a/b.txt/c", false},
+		{matches.MatchBaseName, "/a/node_modules", true},
+		{matches.MatchBaseName, "/a/node_modules/c.js", false},
+		{matches.MatchAnySegment, "/a/b/c.txt", true},
+		{matches.MatchAnySegment, "/a/b.txt/c", true},
+		{matches.MatchAnySegment, "/a/node_modules/c.js", true},
+		{matches.MatchAnySegment, "/a/b/c.js", false},
+		{matches.MatchAnySegment, "/", false},
+	}
+
+	for _, tc := range tests {
+		s := matches.NewShellPatternPathMatcher([]string{"*.txt", "node_modules"}, matches.WithMatchTarget(tc.target))
+		m, err := s.Match(tc.path)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, m, "target: %d path: %q", tc.target, tc.path)
+	}
+
+	s := matches.NewShellPatternPathMatcher([]string{"*.TXT"}, matches.WithMatchTarget(matches.MatchBaseName), matches.WithCaseInsensitive())
+	m, err := s.Match("/A/B/c.txt")
+	require.NoError(t, err)
+	assert.True(t, m)
+
+	s = matches.NewShellPatternPathMatcher([]string{"[a-"}, matches.WithMatchTarget(matches.MatchAnySegment))
+	_, err = s.Match("/a/b")
+	assert.Error(t, err)
+}
+
+func TestGlobPathMatcher(t *testing.T) {
+	g, err := matches.NewGlobPathMatcher([]string{
+		"src/**/*.go",
+		"**/*.{jpg,png}",
+		"docs/{api,guide/{v1,v2}}/*.md",
+		"file[0-9].txt",
+		"data[!a-c]?.csv",
+		"**/.DS_Store",
+		"build/**",
+		"literal\\*.txt",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"src/main.go", true},
+		{"src/a/b/c/main.go", true},
+		{"src/main.txt", false},
+		{"other/src/main.go", false},
+
+		{"logo.png", true},
+		{"images/2024/photo.jpg", true},
+		{"images/photo.gif", false},
+
+		{"docs/api/index.md", true},
+		{"docs/guide/v2/index.md", true},
+		{"docs/guide/v3/index.md", false},
+		{"docs/guide/index.md", false},
+
+		{"file7.txt", true},
+		{"fileA.txt", false},
+		{"sub/file7.txt", false},
+
+		{"datad1.csv", true},
+		{"dataa1.csv", false},
+		{"data/1.csv", false},
+
+		{".DS_Store", true},
+		{"a/b/.DS_Store", true},
+
+		{"build/x", true},
+		{"build/a/b/c", true},
+		{"build", false},
+
+		{"literal*.txt", true},
+		{"literalx.txt", false},
+	}
+
+	for _, tc := range tests {
+		m, err := g.Match(tc.path)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, m, tc.path)
+	}
+}
+
+func TestGlobPathMatcherNonASCII(t *testing.T) {
+	g, err := matches.NewGlobPathMatcher([]string{"docs/café.md", "[é]x", "**/naïve/*.txt", "{résumé,cv}[!ä].pdf"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"docs/café.md", true},
+		{"docs/cafe.md", false},
+		{"éx", true},
+		{"ex", false},
+		{"a/naïve/notes.txt", true},
+		{"a/naive/notes.txt", false},
+		{"résumé1.pdf", true},
+		{"cvö.pdf", true},
+		{"cvä.pdf", false},
+	}
+
+	for _, tc := range tests {
+		m, err := g.Match(tc.path)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, m, tc.path)
+	}
+}
+
+func TestGlobPathMatcherInvalid(t *testing.T) {
+	_, err := matches.NewGlobPathMatcher([]string{"*.{jpg,png"})
+	assert.ErrorContains(t, err, "unterminated brace expansion")
+
+	_, err = matches.NewGlobPathMatcher([]string{"[abc"})
+	assert.ErrorContains(t, err, "unterminated character class")
+}
+
+func TestPathMatchersCaseInsensitive(t *testing.T) {
+	r, err := matches.NewRegexPathMatcher([]string{`\.jpg$`}, matches.WithCaseInsensitive())
+	require.NoError(t, err)
+	m, err := r.Match("/photos/IMG_001.JPG")
+	require.NoError(t, err)
+	assert.True(t, m)
+
+	s := matches.NewShellPatternPathMatcher([]string{"*.JPG", "[A-C]*.txt"}, matches.WithCaseInsensitive())
+	m, err = s.Match("photo.jpg")
+	require.NoError(t, err)
+	assert.True(t, m)
+	m, err = s.Match("Beta.TXT")
+	require.NoError(t, err)
+	assert.True(t, m)
+	m, err = s.Match("delta.txt")
+	require.NoError(t, err)
+	assert.False(t, m)
+
+	g, err := matches.NewGlobPathMatcher([]string{"**/*.{jpg,png}"}, matches.WithCaseInsensitive())
+	require.NoError(t, err)
+	m, err = g.Match("A/B/LOGO.PNG")
+	require.NoError(t, err)
+	assert.True(t, m)
+
+	// Case sensitive by default
+	s = matches.NewShellPatternPathMatcher([]string{"*.JPG"})
+	m, err = s.Match("photo.jpg")
+	require.NoError(t, err)
+	assert.False(t, m)
+}
@@ -22,26 +22,36 @@ package matches
 import (
 	"fmt"
 	"regexp"
+	"slices"
 )
 
 // A list of compiled regular expressions that can be used to match things.
+// Large lists are optimized for MatchesAny and Matches by matching the expressions that are plain literals
+// (e.g. `\.DS_Store`, `^/proc/` or `^exact$`) in a single pass without using regexp.
 type RegexList struct {
-	compiled []*regexp.Regexp
+	compiled    []*regexp.Regexp
+	expressions []string // the expressions as given, used to report which one matched
+	literals    *literalSet
 }
 
 // Create a new RegexList that compiles the given regular expressions.
-func NewRegexList(expressions []string) (*RegexList, error) {
+func NewRegexList(expressions []string, opts ...Option) (*RegexList, error) {
 	l := &RegexList{}
-	err := l.compile(expressions)
+	err := l.compile(expressions, applyOptions(opts))
 	if err != nil {
 		return nil, err
 	}
 	return l, nil
 }
 
-func (l *RegexList) compile(expressions []string) error {
+func (l *RegexList) compile(expressions []string, o options) error {
 	for i, exp := range expressions {
-		r, err := regexp.Compile(exp)
+		expr := exp
+		if o.caseInsensitive {
+			expr = "(?i)" + expr
+		}
+
+		r, err := regexp.Compile(expr)
 		if err != nil {
 			return &RegexListCompileErr{
 				Input: exp,
@@ -50,6 +60,11 @@ func (l *RegexList) compile(expressions []string) error {
 			}
 		}
 		l.compiled = append(l.compiled, r)
+		l.expressions = append(l.expressions, exp)
+	}
+
+	if len(l.compiled) >= optimizeThreshold {
+		l.literals = newLiteralSet(l.compiled)
 	}
 
 	return nil
@@ -57,9 +72,39 @@ func (l *RegexList) compile(expressions []string) error {
 
 // Returns true if the needle matches any of the compiled regular expressions.
 func (l *RegexList) MatchesAny(needle string) bool {
+	if l.literals != nil {
+		return l.literals.matchesAny(needle)
+	}
 	return matchesAnyRegexp(l.compiled, needle)
 }
 
+// Returns the index of the first compiled regular expression that matches the needle and true,
+// or -1 and false if none of them matched.
+func (l *RegexList) MatchesAnyWhich(needle string) (int, bool) {
+	for i, re := range l.compiled {
+		if re.MatchString(needle) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// Returns the regular expressions (as they were given to NewRegexList) that match the needle.
+func (l *RegexList) MatchingPatterns(needle string) []string {
+	var result []string
+	for i, re := range l.compiled {
+		if re.MatchString(needle) {
+			result = append(result, l.expressions[i])
+		}
+	}
+	return result
+}
+
+// Returns the regular expressions in the order they were given to NewRegexList.
+func (l *RegexList) Patterns() []string {
+	return slices.Clone(l.expressions)
+}
+
 // Returns true if the needle matches all of the compiled regular expressions.
 func (l *RegexList) MatchesAll(needle string) bool {
 	return matchesAllRegexp(l.compiled, needle)
@@ -67,6 +112,15 @@ func (l *RegexList) MatchesAll(needle string) bool {
 
 // Returns the slice of needles that matched any of the compiled regular expressions.
 func (l *RegexList) Matches(needles []string) []string {
+	if l.literals != nil {
+		var result []string
+		for _, needle := range needles {
+			if l.literals.matchesAny(needle) {
+				result = append(result, needle)
+			}
+		}
+		return result
+	}
 	return matchesRegexp(l.compiled, needles)
 }
 
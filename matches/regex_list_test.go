@@ -20,6 +20,10 @@
 package matches_test
 
 import (
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/andrejacobs/go-aj/matches"
@@ -69,3 +73,125 @@ func TestRegexListCompileError(t *testing.T) {
 	assert.Equal(t, 2, compErr.Index)
 	assert.Equal(t, `\Knotvalid`, compErr.Input)
 }
+
+func TestRegexListCaseInsensitive(t *testing.T) {
+	l, err := matches.NewRegexList([]string{`\.DS_Store$`, `^/Volumes/`}, matches.WithCaseInsensitive())
+	require.NoError(t, err)
+
+	assert.True(t, l.MatchesAny("a/.ds_store"))
+	assert.True(t, l.MatchesAny("/volumes/backup"))
+	assert.False(t, l.MatchesAny("/Users/volumes/"))
+
+	// The compile error reports the original expression
+	_, err = matches.NewRegexList([]string{`\Knotvalid`}, matches.WithCaseInsensitive())
+	var compErr *matches.RegexListCompileErr
+	require.ErrorAs(t, err, &compErr)
+	assert.Equal(t, `\Knotvalid`, compErr.Input)
+}
+
+func TestRegexListMatchesAnyWhich(t *testing.T) {
+	l, err := matches.NewRegexList([]string{`\bHe`, `\bworld\b`, `\d+`})
+	require.NoError(t, err)
+
+	idx, ok := l.MatchesAnyWhich("the world")
+	assert.True(t, ok)
+	assert.Equal(t, 1, idx)
+
+	idx, ok = l.MatchesAnyWhich("Hello world 42")
+	assert.True(t, ok)
+	assert.Equal(t, 0, idx)
+
+	idx, ok = l.MatchesAnyWhich("The quick brown fox")
+	assert.False(t, ok)
+	assert.Equal(t, -1, idx)
+}
+
+func TestRegexListMatchingPatterns(t *testing.T) {
+	l, err := matches.NewRegexList([]string{`\bhe`, `\bworld\b`, `\d+`}, matches.WithCaseInsensitive())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`\bhe`, `\d+`}, l.MatchingPatterns("Hello 42"))
+	assert.Equal(t, []string{`\bworld\b`}, l.MatchingPatterns("WORLD"))
+	assert.Empty(t, l.MatchingPatterns("The quick brown fox"))
+	assert.Equal(t, []string{`\bhe`, `\bworld\b`, `\d+`}, l.Patterns())
+
+	r, err := matches.NewRegexPathMatcher([]string{`\.DS_Store$`, `^/tmp/`})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`^/tmp/`}, r.MatchingPatterns("/tmp/file.txt"))
+}
+
+func TestRegexListOptimized(t *testing.T) {
+	expressions := []string{
+		`\.DS_Store`, `node_modules`, `^/proc/`, `^/sys/`, `\.tmp$`, `~$`, `^exact/path$`,
+		`he`, `she`, `his`, `hers`, `ushers`, // overlapping literals
+		`(?i)CaseInsensitive`, `\d{4}-\d{2}`, `^$`, `a.c`, `日本`, `[xyz]{3}`,
+	}
+	l, err := matches.NewRegexList(expressions)
+	require.NoError(t, err)
+
+	needles := []string{
+		"", "/home/.DS_Store", "/a/node_modules/b", "/proc/1/status", "/home/proc/x", "/sys/", "/sy",
+		"file.tmp", "file.tmp.bak", "file~", "exact/path", "/exact/path", "exact/path/", "h", "hx", "she", "ush",
+		"ushe", "xhisx", "hers", "caseinsensitive", "2025-10", "abc", "a\nc", "日本語", "xyz", "xy",
+		"nothing here", "\xff\xfe", "/Proc/1",
+	}
+
+	for _, needle := range needles {
+		// MatchesAnyWhich checks each expression one by one
+		_, expected := l.MatchesAnyWhich(needle)
+		assert.Equal(t, expected, l.MatchesAny(needle), "needle: %q", needle)
+	}
+
+	found := l.Matches(needles)
+	for _, needle := range needles {
+		_, expected := l.MatchesAnyWhich(needle)
+		assert.Equal(t, expected, slices.Contains(found, needle), "needle: %q", needle)
+	}
+}
+
+func TestRegexListOptimizedRandom(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	randomString := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "abc/."[rnd.IntN(5)]
+		}
+		return string(b)
+	}
+
+	expressions := make([]string, 0, 200)
+	for range 200 {
+		lit := regexp.QuoteMeta(randomString(1 + rnd.IntN(4)))
+		switch rnd.IntN(5) {
+		case 0:
+			lit = "^" + lit
+		case 1:
+			lit = lit + "$"
+		case 2:
+			lit = "^" + lit + "$"
+		}
+		expressions = append(expressions, lit)
+	}
+
+	l, err := matches.NewRegexList(expressions)
+	require.NoError(t, err)
+
+	for range 2000 {
+		needle := randomString(rnd.IntN(10))
+		_, expected := l.MatchesAnyWhich(needle)
+		require.Equal(t, expected, l.MatchesAny(needle), "needle: %q", needle)
+	}
+}
+
+func BenchmarkRegexListMatchesAny(b *testing.B) {
+	expressions := make([]string, 0, 5000)
+	for i := range 5000 {
+		expressions = append(expressions, regexp.QuoteMeta(fmt.Sprintf("%c%cword_%d.txt", rune('a'+i%26), rune('a'+(i/26)%26), i)))
+	}
+	l, err := matches.NewRegexList(expressions)
+	require.NoError(b, err)
+
+	for b.Loop() {
+		l.MatchesAny("/home/user/projects/something/src/main/file_123.go")
+	}
+}
@@ -21,9 +21,15 @@ package matches
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"regexp"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // Reference on the go regex support: https://github.com/google/re2/wiki/Syntax
@@ -31,13 +37,43 @@ import (
 // RegexScanner is used to read from an io.Reader line by line and then
 // tries to match the line against a set of regular expressions.
 type RegexScanner struct {
-	entries []regexScannerEntry
-	w       io.Writer
+	entries         []regexScannerEntry
+	w               io.Writer
+	maxLineLength   int
+	maxLines        int
+	mode            RegexScannerMode
+	maxDocumentSize int64
 }
 
+// RegexScannerMode determines how the input is matched against the regular expressions.
+type RegexScannerMode int
+
+const (
+	// Match each line on its own. This is the default.
+	RegexScanLines RegexScannerMode = iota
+	// Match the whole input at once so that expressions can match across line boundaries,
+	// e.g. a stack trace that spans multiple lines. The input is read into memory and is limited by
+	// SetMaxDocumentSize. Use the (?m) flag for ^ and $ to match at line boundaries and (?s) for . to match \n.
+	RegexScanDocument
+)
+
+// DefaultMaxDocumentSize is the maximum number of bytes read by RegexScanDocument mode unless changed
+// using SetMaxDocumentSize.
+const DefaultMaxDocumentSize = 16 * 1024 * 1024
+
+// ProcessReplace was called without an io.Writer set using SetOut.
+var ErrNoOut = errors.New("matches: no io.Writer has been set")
+
+// The input is larger than the maximum size allowed in RegexScanDocument mode.
+var ErrDocumentTooLarge = errors.New("matches: the document exceeds the maximum size")
+
 // Function that will be called when a regular expression found some matches.
 type RegexScannerFoundMatches func(key string, line string, lineNumber int, matches []string) error
 
+// Function that will be called when a regular expression with named capture groups found some matches.
+// The named map contains the text captured by each named group, groups that did not participate are empty.
+type RegexScannerFoundNamedMatches func(key string, line string, lineNumber int, named map[string]string) error
+
 // Result from the Process function. A map of the key to matching substrings.
 // NOTE: The result will always contain the last found match for a key (meaning the map is updated on each find).
 type RegexScannerResult map[string][]string
@@ -45,6 +81,35 @@ type RegexScannerResult map[string][]string
 // Register a regular expression that will try and find matches when the Process function is called
 // NOTE: To match case-insensitive add the prefix (?i) to the regular expression.
 func (r *RegexScanner) Add(key string, expression string, foundFn RegexScannerFoundMatches) error {
+	return r.add(key, expression, regexScannerEntry{foundFn: foundFn})
+}
+
+// Register a regular expression with named capture groups, e.g. `(?P<level>\w+): (?P<msg>.*)`, that will try
+// and find matches when the Process function is called. The foundFn is called with the captures by name.
+func (r *RegexScanner) AddNamed(key string, expression string, foundFn RegexScannerFoundNamedMatches) error {
+	return r.add(key, expression, regexScannerEntry{namedFn: foundFn})
+}
+
+// Register a regular expression along with a replacement template that will be used by the ProcessReplace function
+// to rewrite the input. Inside the template $1 or ${name} is replaced by the text of the capture group,
+// see regexp.Regexp.Expand for the details.
+// The Process function treats the expression like any other registered using Add without a function.
+func (r *RegexScanner) AddReplace(key string, expression string, template string) error {
+	return r.add(key, expression, regexScannerEntry{template: &template})
+}
+
+// Return the named capture groups of the expression registered with the key from the positional matches
+// (e.g. from RegexScannerResult). Returns nil if the key is not registered or there are no named groups.
+func (r *RegexScanner) NamedCaptures(key string, matches []string) map[string]string {
+	for _, entry := range r.entries {
+		if entry.key == key {
+			return entry.named(matches)
+		}
+	}
+	return nil
+}
+
+func (r *RegexScanner) add(key string, expression string, entry regexScannerEntry) error {
 	regex, err := regexp.Compile(expression)
 	if err != nil {
 		return fmt.Errorf("failed to compile the regular expression for the key: %q expression: %q. %w", key, expression, err)
@@ -54,11 +119,9 @@ func (r *RegexScanner) Add(key string, expression string, foundFn RegexScannerFo
 		r.entries = make([]regexScannerEntry, 0, 4)
 	}
 
-	r.entries = append(r.entries, regexScannerEntry{
-		key:     key,
-		regex:   regex,
-		foundFn: foundFn,
-	})
+	entry.key = key
+	entry.regex = regex
+	r.entries = append(r.entries, entry)
 
 	return nil
 }
@@ -69,14 +132,52 @@ func (r *RegexScanner) SetOut(w io.Writer) {
 	r.w = w
 }
 
+// Set the maximum length in bytes of a line that can be read by the Process method.
+// Reading a longer line will fail with bufio.ErrTooLong. The default is bufio.MaxScanTokenSize (64 KiB).
+func (r *RegexScanner) SetMaxLineLength(n int) {
+	r.maxLineLength = n
+}
+
+// Set the maximum number of lines that will be read by the Process method after which it stops without an error.
+// The default of 0 means there is no limit.
+func (r *RegexScanner) SetMaxLines(n int) {
+	r.maxLines = n
+}
+
+// Set how the input is matched, see RegexScannerMode.
+func (r *RegexScanner) SetMode(mode RegexScannerMode) {
+	r.mode = mode
+}
+
+// Set the maximum number of bytes that will be read in RegexScanDocument mode.
+// Reading more will fail with ErrDocumentTooLarge. The default is DefaultMaxDocumentSize.
+func (r *RegexScanner) SetMaxDocumentSize(n int64) {
+	r.maxDocumentSize = n
+}
+
 // Read line by line from the io.Reader and try and find matching regular expressions.
 // The read line will be written to any writter set by SetOut method.
 func (r *RegexScanner) Process(rd io.Reader) (RegexScannerResult, error) {
-	scanner := bufio.NewScanner(rd)
+	return r.ProcessContext(context.Background(), rd)
+}
+
+// Read line by line from the io.Reader and try and find matching regular expressions.
+// The context is checked before each line is processed and the cause of the cancellation is returned
+// along with the results found so far. A Read call that blocks on the io.Reader is not interrupted.
+func (r *RegexScanner) ProcessContext(ctx context.Context, rd io.Reader) (RegexScannerResult, error) {
+	if r.mode == RegexScanDocument {
+		return r.processDocument(ctx, rd)
+	}
+
+	scanner := r.newLineScanner(rd)
 	result := make(RegexScannerResult)
 
 	lineNumber := 0
-	for scanner.Scan() {
+	for (r.maxLines <= 0 || lineNumber < r.maxLines) && scanner.Scan() {
+		if ctx.Err() != nil {
+			return result, context.Cause(ctx)
+		}
+
 		line := scanner.Text()
 
 		if r.w != nil {
@@ -89,27 +190,309 @@ func (r *RegexScanner) Process(rd io.Reader) (RegexScannerResult, error) {
 			found := entry.regex.FindStringSubmatch(line)
 			if found != nil {
 				result[entry.key] = found
-				if entry.foundFn != nil {
-					err := entry.foundFn(entry.key, line, lineNumber, found)
-					if err != nil {
-						return result, err
-					}
+				if err := entry.found(line, lineNumber, found); err != nil {
+					return result, err
 				}
 			}
 		}
 		lineNumber++
 	}
 	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read line %d. %w", lineNumber, err)
+	}
+
+	return result, nil
+}
+
+// Result from the ProcessReplace function. A map of the key to the number of replacements made.
+type RegexScannerReplaceResult map[string]int
+
+// Read from the io.Reader, rewrite the input using the expressions registered with AddReplace and write the
+// result to the io.Writer set by SetOut. Expressions are applied in the order they were registered, each to the output
+// of the previous one. Expressions registered using Add or AddNamed are matched against the rewritten text at their
+// position in that order and have their functions called.
+// In RegexScanLines mode each line is rewritten on its own and written followed by a newline, reading stops after the
+// maximum number of lines (see SetMaxLines) and the remaining input is not written.
+// Returns ErrNoOut if no io.Writer has been set.
+func (r *RegexScanner) ProcessReplace(ctx context.Context, rd io.Reader) (RegexScannerReplaceResult, error) {
+	result := make(RegexScannerReplaceResult)
+	if r.w == nil {
+		return result, ErrNoOut
+	}
+
+	if r.mode == RegexScanDocument {
+		maxSize := r.maxDocumentSize
+		if maxSize <= 0 {
+			maxSize = DefaultMaxDocumentSize
+		}
+
+		doc, err := readDocument(ctx, rd, maxSize)
+		if err != nil {
+			return result, err
+		}
+
+		doc, err = r.replace(doc, 0, result)
+		if err != nil {
+			return result, err
+		}
+
+		_, err = io.WriteString(r.w, doc)
 		return result, err
 	}
 
+	scanner := r.newLineScanner(rd)
+
+	lineNumber := 0
+	for (r.maxLines <= 0 || lineNumber < r.maxLines) && scanner.Scan() {
+		if ctx.Err() != nil {
+			return result, context.Cause(ctx)
+		}
+
+		line, err := r.replace(scanner.Text(), lineNumber, result)
+		if err != nil {
+			return result, err
+		}
+
+		if _, err := io.WriteString(r.w, line+"\n"); err != nil {
+			return result, err
+		}
+		lineNumber++
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read line %d. %w", lineNumber, err)
+	}
+
+	return result, nil
+}
+
+// Apply the replacements in order and call the functions of the other expressions that match.
+func (r *RegexScanner) replace(text string, lineNumber int, result RegexScannerReplaceResult) (string, error) {
+	for _, entry := range r.entries {
+		if entry.template == nil {
+			found := entry.regex.FindStringSubmatch(text)
+			if found != nil {
+				if err := entry.found(text, lineNumber, found); err != nil {
+					return text, err
+				}
+			}
+			continue
+		}
+
+		count := len(entry.regex.FindAllStringIndex(text, -1))
+		if count > 0 {
+			result[entry.key] += count
+			text = entry.regex.ReplaceAllString(text, *entry.template)
+		}
+	}
+	return text, nil
+}
+
+// Process many sources concurrently using up to workers goroutines and return the results per source name.
+// The compiled expressions are shared between the workers, which means the registered functions need to be
+// safe for concurrent use. Lines written to the writer set by SetOut are not interleaved mid-line but lines from
+// different sources are. Sources that failed are returned as a joined error and their partial results are kept.
+func (r *RegexScanner) ProcessAll(ctx context.Context, readers map[string]io.Reader, workers int) (map[string]RegexScannerResult, error) {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	// Process in a deterministic order
+	names := make([]string, 0, len(readers))
+	for name := range readers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	worker := *r
+	if r.w != nil {
+		worker.w = &lockedWriter{w: r.w}
+	}
+
+	results := make(map[string]RegexScannerResult, len(readers))
+	errs := make([]error, len(names))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	next := make(chan int)
+	for range min(workers, len(names)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				result, err := worker.ProcessContext(ctx, readers[names[i]])
+				if err != nil {
+					errs[i] = fmt.Errorf("failed to process %q. %w", names[i], err)
+				}
+
+				mu.Lock()
+				results[names[i]] = result
+				mu.Unlock()
+			}
+		}()
+	}
+
+	dispatched := 0
+dispatch:
+	for i := range names {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case next <- i:
+			dispatched++
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if dispatched < len(names) {
+		errs = append(errs, context.Cause(ctx))
+	}
+	return results, errors.Join(errs...)
+}
+
+// Read the whole input and find all the matches of each regular expression in it.
+// The callback receives the complete lines spanned by the match and the line number at which the match starts.
+func (r *RegexScanner) processDocument(ctx context.Context, rd io.Reader) (RegexScannerResult, error) {
+	result := make(RegexScannerResult)
+
+	maxSize := r.maxDocumentSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDocumentSize
+	}
+
+	doc, err := readDocument(ctx, rd, maxSize)
+	if err != nil {
+		return result, err
+	}
+
+	if r.w != nil {
+		if _, err := io.WriteString(r.w, doc); err != nil {
+			return result, err
+		}
+	}
+
+	for _, entry := range r.entries {
+		lineNumber := 0
+		lineCursor := 0
+		for _, loc := range entry.regex.FindAllStringSubmatchIndex(doc, -1) {
+			if ctx.Err() != nil {
+				return result, context.Cause(ctx)
+			}
+
+			start, end := loc[0], loc[1]
+			lineNumber += strings.Count(doc[lineCursor:start], "\n")
+			lineCursor = start
+
+			found := make([]string, len(loc)/2)
+			for i := range found {
+				if loc[2*i] >= 0 {
+					found[i] = doc[loc[2*i]:loc[2*i+1]]
+				}
+			}
+
+			result[entry.key] = found
+			if entry.foundFn != nil || entry.namedFn != nil {
+				lineStart := strings.LastIndexByte(doc[:start], '\n') + 1
+				lineEnd := len(doc)
+				if idx := strings.IndexByte(doc[end:], '\n'); idx >= 0 {
+					lineEnd = end + idx
+				}
+				// A match ending with a newline does not include the next line
+				if end > start && doc[end-1] == '\n' {
+					lineEnd = end - 1
+				}
+
+				if err := entry.found(doc[lineStart:max(lineStart, lineEnd)], lineNumber, found); err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+
 	return result, nil
 }
 
 //-----------------------------------------------------------------------------
 
+// Read all of the io.Reader while checking the context between reads.
+func readDocument(ctx context.Context, rd io.Reader, maxSize int64) (string, error) {
+	var sb strings.Builder
+	buffer := make([]byte, 32*1024)
+	limited := rd
+	if maxSize < math.MaxInt64 {
+		// Read one more byte than allowed to detect a document that is too large
+		limited = io.LimitReader(rd, maxSize+1)
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return "", context.Cause(ctx)
+		}
+
+		n, err := limited.Read(buffer)
+		sb.Write(buffer[:n])
+		if int64(sb.Len()) > maxSize {
+			return "", ErrDocumentTooLarge
+		}
+		if errors.Is(err, io.EOF) {
+			return sb.String(), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read the document. %w", err)
+		}
+	}
+}
+
+func (r *RegexScanner) newLineScanner(rd io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(rd)
+	if r.maxLineLength > 0 {
+		scanner.Buffer(make([]byte, 0, min(r.maxLineLength, bufio.MaxScanTokenSize)), r.maxLineLength)
+	}
+	return scanner
+}
+
+// Serialize the writes from multiple goroutines.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
 type regexScannerEntry struct {
-	key     string
-	regex   *regexp.Regexp
-	foundFn RegexScannerFoundMatches
+	key      string
+	regex    *regexp.Regexp
+	foundFn  RegexScannerFoundMatches
+	namedFn  RegexScannerFoundNamedMatches
+	template *string // replacement template used by ProcessReplace
+}
+
+// Call the registered function with the matches.
+func (e *regexScannerEntry) found(line string, lineNumber int, matches []string) error {
+	if e.foundFn != nil {
+		return e.foundFn(e.key, line, lineNumber, matches)
+	}
+	if e.namedFn != nil {
+		return e.namedFn(e.key, line, lineNumber, e.named(matches))
+	}
+	return nil
+}
+
+// Map the positional matches to the named capture groups.
+func (e *regexScannerEntry) named(matches []string) map[string]string {
+	var result map[string]string
+	for i, name := range e.regex.SubexpNames() {
+		if name == "" || i >= len(matches) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[name] = matches[i]
+	}
+	return result
 }
@@ -20,10 +20,17 @@
 package matches_test
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
 
 	"github.com/andrejacobs/go-aj/matches"
 	"github.com/stretchr/testify/assert"
@@ -104,3 +111,303 @@ func TestRegexScannerWriteToOut(t *testing.T) {
 
 	assert.Equal(t, input+"\n", buf.String())
 }
+
+func TestRegexScannerContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lines := 0
+	r := &matches.RegexScanner{}
+	r.Add("line", "^line", func(key string, line string, lineNumber int, matches []string) error {
+		lines++
+		if lineNumber == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	input := strings.Repeat("line\n", 10)
+	_, err := r.ProcessContext(ctx, strings.NewReader(input))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 3, lines)
+}
+
+func TestRegexScannerMaxLineLength(t *testing.T) {
+	longLine := strings.Repeat("x", 100*1024) + " needle"
+	input := "first\n" + longLine + "\nlast"
+
+	r := &matches.RegexScanner{}
+	r.Add("needle", "needle$", nil)
+
+	_, err := r.Process(strings.NewReader(input))
+	assert.ErrorIs(t, err, bufio.ErrTooLong)
+
+	r.SetMaxLineLength(200 * 1024)
+	result, err := r.Process(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"needle"}, result["needle"])
+
+	r.SetMaxLineLength(10)
+	_, err = r.Process(strings.NewReader(input))
+	assert.ErrorIs(t, err, bufio.ErrTooLong)
+}
+
+func TestRegexScannerMaxLines(t *testing.T) {
+	input := "one\ntwo\nthree\nfour\n"
+
+	r := &matches.RegexScanner{}
+	r.Add("word", "^\\w+$", nil)
+
+	buf := bytes.Buffer{}
+	r.SetOut(&buf)
+	r.SetMaxLines(2)
+	result, err := r.Process(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"two"}, result["word"])
+	assert.Equal(t, "one\ntwo\n", buf.String())
+
+	buf.Reset()
+	r.SetMaxLines(0)
+	result, err = r.Process(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"four"}, result["word"])
+	assert.Equal(t, input, buf.String())
+}
+
+func TestRegexScannerDocumentMode(t *testing.T) {
+	input := `INFO starting
+ERROR something failed
+	at main.go:10
+	at server.go:42
+INFO recovered
+ERROR another failure
+	at db.go:7
+`
+
+	type found struct {
+		line       string
+		lineNumber int
+		trace      string
+	}
+	var calls []found
+
+	r := &matches.RegexScanner{}
+	err := r.Add("trace", `(?m)^ERROR .*\n((?:\t.*\n)+)`, func(key string, line string, lineNumber int, matches []string) error {
+		calls = append(calls, found{line: line, lineNumber: lineNumber, trace: matches[1]})
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Line by line the expression can never match
+	result, err := r.Process(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Empty(t, calls)
+
+	buf := bytes.Buffer{}
+	r.SetOut(&buf)
+	r.SetMode(matches.RegexScanDocument)
+	result, err = r.Process(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, input, buf.String())
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, found{
+		line:       "ERROR something failed\n\tat main.go:10\n\tat server.go:42",
+		lineNumber: 1,
+		trace:      "\tat main.go:10\n\tat server.go:42\n",
+	}, calls[0])
+	assert.Equal(t, found{
+		line:       "ERROR another failure\n\tat db.go:7",
+		lineNumber: 5,
+		trace:      "\tat db.go:7\n",
+	}, calls[1])
+	assert.Equal(t, "\tat db.go:7\n", result["trace"][1])
+}
+
+func TestRegexScannerDocumentModeMaxSize(t *testing.T) {
+	r := &matches.RegexScanner{}
+	r.Add("x", "x", nil)
+	r.SetMode(matches.RegexScanDocument)
+	r.SetMaxDocumentSize(10)
+
+	_, err := r.Process(strings.NewReader(strings.Repeat("x", 11)))
+	assert.ErrorIs(t, err, matches.ErrDocumentTooLarge)
+
+	result, err := r.Process(strings.NewReader(strings.Repeat("x", 10)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x"}, result["x"])
+}
+
+func TestRegexScannerDocumentModeContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := &matches.RegexScanner{}
+	r.Add("x", "x", nil)
+	r.SetMode(matches.RegexScanDocument)
+
+	_, err := r.ProcessContext(ctx, strings.NewReader("x"))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRegexScannerNamedCaptures(t *testing.T) {
+	input := `2025-01-02 ERROR disk full
+2025-01-02 INFO all good
+2025-01-03 WARN low memory`
+
+	var calls []map[string]string
+	var lineNumbers []int
+
+	r := &matches.RegexScanner{}
+	err := r.AddNamed("log", `^(?P<date>\S+) (?P<level>ERROR|WARN) (?P<msg>.*)$`,
+		func(key string, line string, lineNumber int, named map[string]string) error {
+			assert.Equal(t, "log", key)
+			calls = append(calls, named)
+			lineNumbers = append(lineNumbers, lineNumber)
+			return nil
+		})
+	require.NoError(t, err)
+	require.NoError(t, r.Add("positional", `^(\S+) INFO`, nil))
+
+	result, err := r.Process(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]string{
+		{"date": "2025-01-02", "level": "ERROR", "msg": "disk full"},
+		{"date": "2025-01-03", "level": "WARN", "msg": "low memory"},
+	}, calls)
+	assert.Equal(t, []int{0, 2}, lineNumbers)
+
+	assert.Equal(t, map[string]string{"date": "2025-01-03", "level": "WARN", "msg": "low memory"},
+		r.NamedCaptures("log", result["log"]))
+	assert.Nil(t, r.NamedCaptures("positional", result["positional"]))
+	assert.Nil(t, r.NamedCaptures("missing", result["log"]))
+}
+
+func TestRegexScannerNamedCapturesDocumentMode(t *testing.T) {
+	input := "panic: oops\n\ngoroutine 1 [running]:\nmain.main()\n"
+
+	var named map[string]string
+	r := &matches.RegexScanner{}
+	r.SetMode(matches.RegexScanDocument)
+	err := r.AddNamed("panic", `(?m)^panic: (?P<reason>.*)\n\ngoroutine (?P<id>\d+)`,
+		func(key string, line string, lineNumber int, n map[string]string) error {
+			named = n
+			return nil
+		})
+	require.NoError(t, err)
+
+	_, err = r.Process(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"reason": "oops", "id": "1"}, named)
+}
+
+func TestRegexScannerProcessAll(t *testing.T) {
+	readers := make(map[string]io.Reader)
+	for i := range 20 {
+		readers[fmt.Sprintf("log%d", i)] = strings.NewReader(fmt.Sprintf("start\nid=%d\nend\n", i))
+	}
+	readers["broken"] = iotest.ErrReader(errors.New("boom"))
+
+	var mu sync.Mutex
+	found := 0
+
+	r := &matches.RegexScanner{}
+	err := r.Add("id", `^id=(\d+)$`, func(key string, line string, lineNumber int, matches []string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		found++
+		return nil
+	})
+	require.NoError(t, err)
+
+	buf := bytes.Buffer{}
+	r.SetOut(&buf)
+
+	results, err := r.ProcessAll(context.Background(), readers, 4)
+	assert.ErrorContains(t, err, `failed to process "broken"`)
+	assert.ErrorContains(t, err, "boom")
+
+	assert.Len(t, results, 21)
+	for i := range 20 {
+		result := results[fmt.Sprintf("log%d", i)]
+		assert.Equal(t, []string{fmt.Sprintf("id=%d", i), fmt.Sprintf("%d", i)}, result["id"])
+	}
+	assert.Equal(t, 20, found)
+	assert.Equal(t, 20, strings.Count(buf.String(), "start\n"))
+	assert.Equal(t, 20*3, strings.Count(buf.String(), "\n"))
+}
+
+func TestRegexScannerProcessAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := &matches.RegexScanner{}
+	r.Add("x", "x", nil)
+
+	readers := map[string]io.Reader{
+		"a": strings.NewReader("x"),
+		"b": strings.NewReader("x"),
+	}
+	_, err := r.ProcessAll(ctx, readers, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRegexScannerProcessReplace(t *testing.T) {
+	input := `user=alice ip=10.0.0.1 token=abc123
+user=bob ip=10.0.0.2
+nothing to see here`
+
+	var seen []string
+	r := &matches.RegexScanner{}
+	require.NoError(t, r.AddReplace("ip", `\b\d+\.\d+\.\d+\.\d+\b`, "x.x.x.x"))
+	require.NoError(t, r.AddReplace("token", `token=(?P<value>\w+)`, "token=<redacted:${value}>"))
+	require.NoError(t, r.AddReplace("user", `user=(\w+)`, "user=$1"))
+	require.NoError(t, r.Add("seen", `ip=x`, func(key string, line string, lineNumber int, matches []string) error {
+		seen = append(seen, line)
+		return nil
+	}))
+
+	_, err := r.ProcessReplace(context.Background(), strings.NewReader(input))
+	assert.ErrorIs(t, err, matches.ErrNoOut)
+
+	buf := bytes.Buffer{}
+	r.SetOut(&buf)
+	result, err := r.ProcessReplace(context.Background(), strings.NewReader(input))
+	require.NoError(t, err)
+
+	expected := `user=alice ip=x.x.x.x token=<redacted:abc123>
+user=bob ip=x.x.x.x
+nothing to see here
+`
+	assert.Equal(t, expected, buf.String())
+	assert.Equal(t, matches.RegexScannerReplaceResult{"ip": 2, "token": 1, "user": 2}, result)
+	assert.Equal(t, []string{"user=alice ip=x.x.x.x token=<redacted:abc123>", "user=bob ip=x.x.x.x"}, seen)
+}
+
+func TestRegexScannerProcessReplaceDocumentMode(t *testing.T) {
+	input := "BEGIN secret\nline 1\nline 2\nEND\nkeep me\n"
+
+	r := &matches.RegexScanner{}
+	r.SetMode(matches.RegexScanDocument)
+	require.NoError(t, r.AddReplace("block", `(?s)BEGIN (\w+)\n.*?END\n`, "[$1 removed]\n"))
+
+	buf := bytes.Buffer{}
+	r.SetOut(&buf)
+	result, err := r.ProcessReplace(context.Background(), strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, "[secret removed]\nkeep me\n", buf.String())
+	assert.Equal(t, matches.RegexScannerReplaceResult{"block": 1}, result)
+}
+
+func TestRegexScannerDocumentModeMaxSizeLimit(t *testing.T) {
+	r := &matches.RegexScanner{}
+	r.Add("x", "x+", nil)
+	r.SetMode(matches.RegexScanDocument)
+	r.SetMaxDocumentSize(math.MaxInt64)
+
+	result, err := r.Process(strings.NewReader("xxx"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"xxx"}, result["x"])
+}
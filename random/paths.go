@@ -33,11 +33,15 @@ import (
 // minNameLen, maxNameLen: random range of length of characters used to generate each random subdirectory's name.
 // The function will always return the base + range(min, max) paths.
 func Path(base string, minDirs int, maxDirs int, minNameLen int, maxNameLen int) string {
+	return randomPath(globalGenerator{}, base, minDirs, maxDirs, minNameLen, maxNameLen)
+}
+
+func randomPath(g generator, base string, minDirs int, maxDirs int, minNameLen int, maxNameLen int) string {
 	sb := strings.Builder{}
-	count := Int(minDirs, maxDirs)
+	count := g.Int(minDirs, maxDirs)
 	minNameLen = max(1, minNameLen)
 	for depth := 0; depth < count; depth++ {
-		sb.WriteString(String(Int(minNameLen, maxNameLen)))
+		sb.WriteString(g.String(g.Int(minNameLen, maxNameLen)))
 		if depth < (count - 1) {
 			sb.WriteRune(os.PathSeparator)
 		}
@@ -48,9 +52,13 @@ func Path(base string, minDirs int, maxDirs int, minNameLen int, maxNameLen int)
 // Generate a slice of random paths
 // count: is the number of random paths to create and return
 func Paths(base string, count int, min int, max int, minNameLen int, maxNameLen int) []string {
+	return randomPaths(globalGenerator{}, base, count, min, max, minNameLen, maxNameLen)
+}
+
+func randomPaths(g generator, base string, count int, min int, max int, minNameLen int, maxNameLen int) []string {
 	paths := make([]string, 0, count)
 	for i := 0; i < count; i++ {
-		paths = append(paths, Path(base, min, max, minNameLen, maxNameLen))
+		paths = append(paths, randomPath(g, base, min, max, minNameLen, maxNameLen))
 	}
 	return paths
 }
@@ -68,14 +76,21 @@ func CreateFiles(dir string,
 	minFiles int, maxFiles int,
 	minSize uint64, maxSize uint64,
 	maxTotalSize uint64) (uint64, error) {
+	return createRandomFiles(globalGenerator{}, dir, minFiles, maxFiles, minSize, maxSize, maxTotalSize)
+}
+
+func createRandomFiles(g generator, dir string,
+	minFiles int, maxFiles int,
+	minSize uint64, maxSize uint64,
+	maxTotalSize uint64) (uint64, error) {
 
 	currentTotalSize := uint64(0)
 
-	for i := 0; i < Int(minFiles, maxFiles); i++ {
-		path := path.Join(dir, fmt.Sprintf("%s-%d", String(Int(1, 16)), i))
+	for i := 0; i < g.Int(minFiles, maxFiles); i++ {
+		path := path.Join(dir, fmt.Sprintf("%s-%d", g.String(g.Int(1, 16)), i))
 		if currentTotalSize < maxTotalSize {
-			amount := min(int64(Int(0, int(maxSize))), int64(maxTotalSize-currentTotalSize))
-			err := CreateFile(path, amount)
+			amount := min(int64(g.Int(0, int(maxSize))), int64(maxTotalSize-currentTotalSize))
+			err := g.CreateFile(path, amount)
 			if err != nil {
 				return currentTotalSize, err
 			}
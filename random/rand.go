@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package random
+
+import (
+	"io"
+	"math/rand"
+	"os"
+)
+
+// Rand generates random data using its own seedable pseudo-random number generator instead of the
+// package level one. Using the same seed produces the same strings, paths and files which makes it possible
+// to reproduce the data used in unit-testing. Files are filled with bytes from the generator and not from
+// the crypto random generator.
+// Rand is not safe for concurrent use by multiple goroutines, create one per goroutine (e.g. per test) instead.
+type Rand struct {
+	seed int64
+	rnd  *rand.Rand
+}
+
+// Create a new Rand that uses the seed to initialize the pseudo-random number generator.
+func NewRand(seed int64) *Rand {
+	return &Rand{
+		seed: seed,
+		rnd:  rand.New(rand.NewSource(seed)), // #nosec G404 -- Not used for crypto
+	}
+}
+
+// Return the seed that was used to create the Rand, e.g. to log it so that a failure can be reproduced.
+func (r *Rand) Seed() int64 {
+	return r.seed
+}
+
+// String produces a string of length n that contains random characters.
+// Characters are chosen from the following set: abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.
+func (r *Rand) String(n int) string {
+	return randomString(r.rnd, n)
+}
+
+// Int returns a random integer between the minimum and maximum.
+func (r *Rand) Int(min int, max int) int {
+	return r.rnd.Intn(max-min+1) + min
+}
+
+// Read generates len(p) random bytes and writes them into p. It always returns len(p) and a nil error.
+func (r *Rand) Read(p []byte) (int, error) {
+	return r.rnd.Read(p)
+}
+
+// Generate a path consisting of random depth (subdirectories), see the package level Path for details.
+func (r *Rand) Path(base string, minDirs int, maxDirs int, minNameLen int, maxNameLen int) string {
+	return randomPath(r, base, minDirs, maxDirs, minNameLen, maxNameLen)
+}
+
+// Generate a slice of random paths, see the package level Paths for details.
+func (r *Rand) Paths(base string, count int, min int, max int, minNameLen int, maxNameLen int) []string {
+	return randomPaths(r, base, count, min, max, minNameLen, maxNameLen)
+}
+
+// Create a file and fill it with random bytes.
+// NOTE: This will override any existing file.
+func (r *Rand) CreateFile(path string, size int64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.CopyN(f, r, size)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Generate random files inside the specified directory, see the package level CreateFiles for details.
+// Return the total number of bytes written.
+func (r *Rand) CreateFiles(dir string,
+	minFiles int, maxFiles int,
+	minSize uint64, maxSize uint64,
+	maxTotalSize uint64) (uint64, error) {
+	return createRandomFiles(r, dir, minFiles, maxFiles, minSize, maxSize, maxTotalSize)
+}
+
+//-----------------------------------------------------------------------------
+
+// The random data needed to generate paths and files.
+type generator interface {
+	String(n int) string
+	Int(min int, max int) int
+	CreateFile(path string, size int64) error
+}
+
+// Use the package level functions.
+type globalGenerator struct{}
+
+func (globalGenerator) String(n int) string {
+	return String(n)
+}
+
+func (globalGenerator) Int(min int, max int) int {
+	return Int(min, max)
+}
+
+func (globalGenerator) CreateFile(path string, size int64) error {
+	return CreateFile(path, size)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Andre Jacobs
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package random_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andrejacobs/go-aj/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandDeterministic(t *testing.T) {
+	r1 := random.NewRand(42)
+	r2 := random.NewRand(42)
+	r3 := random.NewRand(43)
+	assert.Equal(t, int64(42), r1.Seed())
+
+	s1 := r1.String(32)
+	assert.Len(t, s1, 32)
+	assert.Equal(t, s1, r2.String(32))
+	assert.NotEqual(t, s1, r3.String(32))
+
+	for range 100 {
+		x := r1.Int(10, 42)
+		assert.GreaterOrEqual(t, x, 10)
+		assert.LessOrEqual(t, x, 42)
+		assert.Equal(t, x, r2.Int(10, 42))
+	}
+
+	b1 := make([]byte, 64)
+	b2 := make([]byte, 64)
+	n, err := r1.Read(b1)
+	require.NoError(t, err)
+	assert.Equal(t, 64, n)
+	_, err = r2.Read(b2)
+	require.NoError(t, err)
+	assert.Equal(t, b1, b2)
+
+	paths := r1.Paths("dir1", 10, 2, 5, 1, 10)
+	assert.Len(t, paths, 10)
+	assert.Equal(t, paths, r2.Paths("dir1", 10, 2, 5, 1, 10))
+	for _, p := range paths {
+		assert.True(t, strings.HasPrefix(p, "dir1"))
+	}
+
+	parts := strings.Split(r1.Path("dir1", 3, 3, 4, 4), string(os.PathSeparator))
+	assert.Len(t, parts, 4)
+	assert.Equal(t, "dir1", parts[0])
+	assert.Len(t, parts[1], 4)
+}
+
+func TestRandCreateFiles(t *testing.T) {
+	t.Parallel()
+
+	create := func(seed int64) (string, uint64) {
+		dir := t.TempDir()
+		wc, err := random.NewRand(seed).CreateFiles(dir, 4, 10, 4, 20, 100)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, wc, uint64(100))
+		return dir, wc
+	}
+
+	dir1, wc1 := create(7)
+	dir2, wc2 := create(7)
+	assert.Equal(t, wc1, wc2)
+
+	entries1, err := os.ReadDir(dir1)
+	require.NoError(t, err)
+	entries2, err := os.ReadDir(dir2)
+	require.NoError(t, err)
+	require.Equal(t, len(entries1), len(entries2))
+	assert.NotEmpty(t, entries1)
+
+	for i := range entries1 {
+		assert.Equal(t, entries1[i].Name(), entries2[i].Name())
+
+		data1, err := os.ReadFile(filepath.Join(dir1, entries1[i].Name()))
+		require.NoError(t, err)
+		data2, err := os.ReadFile(filepath.Join(dir2, entries2[i].Name()))
+		require.NoError(t, err)
+		assert.Equal(t, data1, data2)
+	}
+}
+
+func TestRandCreateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unit-testing")
+	require.NoError(t, random.NewRand(1).CreateFile(path, 100))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), info.Size())
+}
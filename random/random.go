@@ -45,6 +45,10 @@ var src = rand.NewSource(time.Now().UnixNano())
 // String produces a string of length n that contains random characters.
 // Characters are chosen from the following set: abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.
 func String(n int) string {
+	return randomString(src, n)
+}
+
+func randomString(src rand.Source, n int) string {
 	sb := strings.Builder{}
 	sb.Grow(n)
 	// A src.Int63() generates 63 random bits, enough for letterIdxMax characters!